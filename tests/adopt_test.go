@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+const adoptedProjectID = "0184b8f5-1111-2222-3333-444455556666"
+
+// conflictingProjectAPI rejects project creation as a duplicate and serves an
+// existing project named "demo" owned by "my-org", which it never modifies.
+func conflictingProjectAPI(t *testing.T, requests *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+		project := map[string]interface{}{
+			"id":          adoptedProjectID,
+			"name":        "demo",
+			"owner":       map[string]interface{}{"id": 42, "slug": "my-org"},
+			"description": "Maintained by hand",
+			"url":         "https://app.valohai.com/api/v0/projects/" + adoptedProjectID + "/",
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v0/projects/":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"non_field_errors": [{"message": "Project with this name already exists", "code": "unique"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v0/projects/":
+			if r.URL.Query().Get("name") != "demo" {
+				t.Errorf("expected lookup by name, got %q", r.URL.RawQuery)
+			}
+			other := map[string]interface{}{"id": "other", "name": "demo", "owner": map[string]interface{}{"id": 7, "slug": "someone-else"}}
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": []interface{}{other, project}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v0/projects/"+adoptedProjectID+"/":
+			json.NewEncoder(w).Encode(project)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestProjectCreateAdoptsExisting(t *testing.T) {
+	var requests []string
+	r := valohai.ResourceProject()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":           "demo",
+		"owner":          "my-org",
+		"description":    "Managed by Terraform",
+		"adopt_existing": true,
+	})
+	if diags := r.CreateContext(context.Background(), d, mockAPIMeta(t, conflictingProjectAPI(t, &requests))); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != adoptedProjectID {
+		t.Fatalf("expected project %s to be adopted, got %q (requests: %v)", adoptedProjectID, d.Id(), requests)
+	}
+	if d.Get("url") == "" {
+		t.Error("expected adopted project to be read into state")
+	}
+	// Adoption must not overwrite the project: the next plan shows the drift
+	if d.Get("description") != "Maintained by hand" {
+		t.Errorf("expected the remote description in state, got %q", d.Get("description"))
+	}
+}
+
+func TestProjectCreateValidationErrorNotAdopted(t *testing.T) {
+	var requests []string
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"template_url": ["Enter a valid URL."]}`))
+	})
+	r := valohai.ResourceProject()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":           "demo",
+		"owner":          "my-org",
+		"template_url":   "not a url",
+		"adopt_existing": true,
+	})
+	if diags := r.CreateContext(context.Background(), d, meta); !diags.HasError() {
+		t.Fatal("expected the validation error")
+	}
+	if len(requests) != 1 || d.Id() != "" {
+		t.Errorf("expected no lookup for a validation error, got %v", requests)
+	}
+}
+
+func TestProjectCreateConflictWithoutAdopt(t *testing.T) {
+	var requests []string
+	r := valohai.ResourceProject()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":  "demo",
+		"owner": "my-org",
+	})
+	diags := r.CreateContext(context.Background(), d, mockAPIMeta(t, conflictingProjectAPI(t, &requests)))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "already exists") {
+		t.Fatalf("expected the create conflict error, got %v", diags)
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected no lookup without adopt_existing, got %v", requests)
+	}
+}
+
+func TestProjectCreateAdoptOwnerMismatch(t *testing.T) {
+	var requests []string
+	r := valohai.ResourceProject()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":           "demo",
+		"owner":          "another-org",
+		"adopt_existing": true,
+	})
+	diags := r.CreateContext(context.Background(), d, mockAPIMeta(t, conflictingProjectAPI(t, &requests)))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "already exists") {
+		t.Fatalf("expected the create conflict error, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected nothing to be adopted, got %q", d.Id())
+	}
+}
+
+func TestTeamCreateAdoptsExisting(t *testing.T) {
+	const teamID = "0184b8f5-9999-2222-3333-444455556666"
+	team := map[string]interface{}{
+		"id":           teamID,
+		"name":         "ml",
+		"organization": map[string]interface{}{"id": 42, "username": "my-org"},
+		"url":          "https://app.valohai.com/api/v0/teams/" + teamID + "/",
+	}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"name": ["Team with this name already exists"]}`))
+		case r.URL.Path == "/api/v0/teams/":
+			if r.URL.Query().Get("organization") != "42" {
+				t.Errorf("expected lookup by organization, got %q", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": []interface{}{team}})
+		default:
+			json.NewEncoder(w).Encode(team)
+		}
+	})
+
+	r := valohai.ResourceTeam()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":           "ml",
+		"organization":   42,
+		"adopt_existing": true,
+	})
+	if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != teamID {
+		t.Fatalf("expected team %s to be adopted, got %q", teamID, d.Id())
+	}
+}
@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+func configuredHTTPClient(t *testing.T, raw map[string]interface{}) *http.Client {
+	t.Helper()
+	provider := valohai.Provider()
+	raw["token"] = "test-token"
+	data := schema.TestResourceDataRaw(t, provider.Schema, raw)
+	meta, diags := provider.ConfigureContextFunc(context.Background(), data)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	return meta.(map[string]interface{})["http_client"].(*http.Client)
+}
+
+func TestHTTPClientMaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := configuredHTTPClient(t, map[string]interface{}{
+		"requests_per_second":     0.0,
+		"max_concurrent_requests": 2,
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("request failed: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", peak)
+	}
+}
+
+func TestHTTPClientRequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := configuredHTTPClient(t, map[string]interface{}{
+		"requests_per_second":     20.0,
+		"max_concurrent_requests": 0,
+	})
+
+	// The first 20 requests use the burst, the next 10 need ~0.5s of refill
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected requests to be throttled, took %s", elapsed)
+	}
+}
+
+func TestHTTPClientUserAgent(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	provider := valohai.New("1.2.3")()
+	provider.TerraformVersion = "1.9.0"
+	data := schema.TestResourceDataRaw(t, provider.Schema, map[string]interface{}{
+		"token":             "test-token",
+		"user_agent_suffix": "team-ml/retrain",
+	})
+	meta, diags := provider.ConfigureContextFunc(context.Background(), data)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	client := meta.(map[string]interface{})["http_client"].(*http.Client)
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	want := "terraform-provider-valohai/1.2.3 (+terraform 1.9.0) team-ml/retrain"
+	if got != want {
+		t.Fatalf("expected User-Agent %q, got %q", want, got)
+	}
+}
+
+func TestHTTPClientCACertPEMOverridesEnvFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// A CA file from the environment must neither conflict with nor replace ca_cert_pem
+	t.Setenv("VALOHAI_CA_CERT_FILE", filepath.Join(t.TempDir(), "missing.pem"))
+	client := configuredHTTPClient(t, map[string]interface{}{"ca_cert_pem": string(caPEM)})
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the server certificate to be trusted through ca_cert_pem: %v", err)
+	}
+	resp.Body.Close()
+}
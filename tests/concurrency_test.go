@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+const concurrencyProjectID = "0184b8f5-1111-2222-3333-444455556666"
+
+// updateProjectDescription plans and applies a description change on a
+// project last read at 2024-01-01 against the given API.
+func updateProjectDescription(t *testing.T, handler http.HandlerFunc) diag.Diagnostics {
+	t.Helper()
+	r := valohai.ResourceProject()
+	state := &terraform.InstanceState{
+		ID: concurrencyProjectID,
+		Attributes: map[string]string{
+			"id":                    concurrencyProjectID,
+			"name":                  "demo",
+			"owner":                 "my-org",
+			"description":           "before",
+			"default_notifications": "true",
+			"deletion_protection":   "true",
+			"mtime":                 "2024-01-01T00:00:00Z",
+		},
+	}
+	meta := mockAPIMeta(t, handler)
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "demo",
+		"owner":       "my-org",
+		"description": "after",
+	}), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, diags := r.Apply(context.Background(), state, diff, meta)
+	return diags
+}
+
+func TestProjectUpdateFailsWhenModifiedRemotely(t *testing.T) {
+	puts := 0
+	diags := updateProjectDescription(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			puts++
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":                    concurrencyProjectID,
+			"name":                  "demo",
+			"description":           "edited in the UI",
+			"default_notifications": true,
+			"mtime":                 "2024-02-01T00:00:00Z",
+		})
+	})
+	if !diags.HasError() {
+		t.Fatal("expected the update to fail")
+	}
+	msg := diags[0].Summary
+	if !strings.Contains(msg, "modified in Valohai") || !strings.Contains(msg, `description: "before" in state, "edited in the UI" in Valohai`) {
+		t.Errorf("expected the remote change to be reported, got %q", msg)
+	}
+	if strings.Contains(msg, "default_notifications:") {
+		t.Errorf("expected unchanged attributes to be left out, got %q", msg)
+	}
+	if puts != 0 {
+		t.Errorf("expected no PUT request, got %d", puts)
+	}
+}
+
+func TestProjectUpdateSendsIfMatch(t *testing.T) {
+	var ifMatch string
+	diags := updateProjectDescription(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			ifMatch = r.Header.Get("If-Match")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":          concurrencyProjectID,
+			"name":        "demo",
+			"description": "before",
+			"mtime":       "2024-01-01T00:00:00Z",
+		})
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if ifMatch != `"v1"` {
+		t.Errorf("expected If-Match %q, got %q", `"v1"`, ifMatch)
+	}
+}
+
+func TestProjectUpdatePreconditionFailed(t *testing.T) {
+	diags := updateProjectDescription(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": concurrencyProjectID, "mtime": "2024-01-01T00:00:00Z"})
+	})
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "modified in Valohai during the update") {
+		t.Fatalf("expected a precondition error, got %v", diags)
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+func TestDatasetVersionDataSourceLatest(t *testing.T) {
+	var query map[string]string
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		query = map[string]string{}
+		for k := range r.URL.Query() {
+			query[k] = r.URL.Query().Get(k)
+		}
+		results := []interface{}{}
+		if query["name"] != "missing" {
+			results = append(results, map[string]interface{}{
+				"id":      "dv2",
+				"name":    "v2",
+				"ctime":   "2026-10-18T12:00:00Z",
+				"dataset": map[string]interface{}{"id": "ds1", "name": "training"},
+				"files":   []interface{}{map[string]interface{}{"datum": "d1"}},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+	})
+
+	state, resp := frameworkDataSourceRead(t, valohai.DatasetVersionDataSource(), meta, map[string]interface{}{"dataset": "ds1"})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if query["dataset"] != "ds1" || query["ordering"] != "-ctime" {
+		t.Errorf("expected the newest version of ds1 to be requested, got %v", query)
+	}
+	if _, ok := query["name"]; ok {
+		t.Errorf("expected no name filter for latest, got %v", query)
+	}
+	if got := stateString(t, state, "version"); got != "v2" {
+		t.Errorf("expected version v2, got %q", got)
+	}
+	if got := stateString(t, state, "uri"); got != "dataset://training/v2" {
+		t.Errorf("expected uri dataset://training/v2, got %q", got)
+	}
+
+	_, resp = frameworkDataSourceRead(t, valohai.DatasetVersionDataSource(), meta, map[string]interface{}{"dataset": "ds1", "name": "missing"})
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for a missing version")
+	}
+}
+
+func TestDatasetVersionDataSourceExactName(t *testing.T) {
+	var pages []string
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") != "v1" {
+			t.Errorf("expected a name filter, got %q", r.URL.RawQuery)
+		}
+		pages = append(pages, r.URL.Query().Get("page"))
+		// The name filter also matches longer names: v1 is only on the second page
+		version := map[string]interface{}{"id": "dv10", "name": "v10", "dataset": map[string]interface{}{"id": "ds1", "name": "training"}}
+		var next interface{} = "https://app.valohai.com/api/v0/dataset-versions/?dataset=ds1&name=v1&page=2"
+		if r.URL.Query().Get("page") == "2" {
+			version = map[string]interface{}{"id": "dv1", "name": "v1", "dataset": map[string]interface{}{"id": "ds1", "name": "training"}}
+			next = nil
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": 2, "next": next, "results": []interface{}{version}})
+	})
+
+	state, resp := frameworkDataSourceRead(t, valohai.DatasetVersionDataSource(), meta, map[string]interface{}{"dataset": "ds1", "name": "v1"})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if got := stateString(t, state, "id"); got != "dv1" {
+		t.Errorf("expected the version named exactly v1, got %q (pages %v)", got, pages)
+	}
+}
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+// environmentResult mirrors one element of valohai_environments.environments.
+type environmentResult struct {
+	ID              string   `tfsdk:"id"`
+	Slug            string   `tfsdk:"slug"`
+	Name            string   `tfsdk:"name"`
+	Enabled         bool     `tfsdk:"enabled"`
+	GPU             bool     `tfsdk:"gpu"`
+	GPUSpec         string   `tfsdk:"gpu_spec"`
+	PerHourPriceUSD *float64 `tfsdk:"per_hour_price_usd"`
+	QueueDepth      int64    `tfsdk:"queue_depth"`
+	IsDefault       bool     `tfsdk:"is_default"`
+}
+
+func readEnvironments(t *testing.T, values map[string]interface{}) ([]environmentResult, string) {
+	t.Helper()
+	var query string
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/environments/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": []interface{}{
+			map[string]interface{}{"id": "e1", "slug": "aws-eu-west-1-t3-medium", "name": "t3.medium", "enabled": true, "per_hour_price_usd": 0.05, "unfinished_job_count": 2, "is_default": true},
+			map[string]interface{}{"id": "e2", "slug": "aws-eu-west-1-p3-2xlarge", "name": "p3.2xlarge", "enabled": true, "gpu_spec": "1x V100", "per_hour_price_usd": 3.06, "unfinished_job_count": 7},
+			map[string]interface{}{"id": "e3", "slug": "aws-eu-west-1-p4d-24xlarge", "name": "p4d.24xlarge", "enabled": false, "gpu_spec": "8x A100"},
+		}})
+	})
+	state, resp := frameworkDataSourceRead(t, valohai.EnvironmentsDataSource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	var envs []environmentResult
+	if diags := state.GetAttribute(context.Background(), path.Root("environments"), &envs); diags.HasError() {
+		t.Fatalf("failed to read environments: %v", diags)
+	}
+	return envs, query
+}
+
+func slugsOf(envs []environmentResult) []string {
+	slugs := []string{}
+	for _, e := range envs {
+		slugs = append(slugs, e.Slug)
+	}
+	return slugs
+}
+
+func TestEnvironmentsDataSource(t *testing.T) {
+	envs, query := readEnvironments(t, map[string]interface{}{"owner": "42"})
+	if query != "owner=42" {
+		t.Errorf("expected the owner filter to be sent, got %q", query)
+	}
+	if len(envs) != 3 {
+		t.Fatalf("expected 3 environments, got %v", envs)
+	}
+	first := envs[0]
+	if !first.IsDefault || first.GPU || first.QueueDepth != 2 || first.PerHourPriceUSD == nil || *first.PerHourPriceUSD != 0.05 {
+		t.Errorf("unexpected first environment %+v", first)
+	}
+	if envs[2].PerHourPriceUSD != nil {
+		t.Errorf("expected a null price when not reported, got %v", *envs[2].PerHourPriceUSD)
+	}
+}
+
+func TestEnvironmentsDataSourceFilters(t *testing.T) {
+	for name, tc := range map[string]struct {
+		values map[string]interface{}
+		want   []string
+	}{
+		"gpu":         {map[string]interface{}{"gpu": true}, []string{"aws-eu-west-1-p3-2xlarge", "aws-eu-west-1-p4d-24xlarge"}},
+		"cpu":         {map[string]interface{}{"gpu": false}, []string{"aws-eu-west-1-t3-medium"}},
+		"enabled gpu": {map[string]interface{}{"gpu": true, "enabled": true}, []string{"aws-eu-west-1-p3-2xlarge"}},
+		"name_regex":  {map[string]interface{}{"name_regex": "^p4d\\."}, []string{"aws-eu-west-1-p4d-24xlarge"}},
+		"no match":    {map[string]interface{}{"name_regex": "^g5\\."}, []string{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			envs, _ := readEnvironments(t, tc.values)
+			if got := slugsOf(envs); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestEnvironmentsDataSourceInvalidRegex(t *testing.T) {
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	_, resp := frameworkDataSourceRead(t, valohai.EnvironmentsDataSource(), meta, map[string]interface{}{"name_regex": "("})
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an invalid name_regex error")
+	}
+}
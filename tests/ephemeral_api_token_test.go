@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+func TestAPITokenEphemeralResourceSchema(t *testing.T) {
+	server, err := valohai.ProviderServer(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("failed to create provider server: %v", err)
+	}
+	resp, err := server().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s, ok := resp.EphemeralResourceSchemas["valohai_api_token"]
+	if !ok {
+		t.Fatal("expected valohai_api_token ephemeral resource to be registered")
+	}
+	found := false
+	for _, attr := range s.Block.Attributes {
+		if attr.Name == "token" {
+			found = true
+			if !attr.Sensitive || !attr.Computed {
+				t.Fatal("expected token to be computed and sensitive")
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected a token attribute")
+	}
+	if _, ok := resp.ResourceSchemas["valohai_api_token"]; ok {
+		t.Fatal("valohai_api_token must not be a managed resource")
+	}
+}
+
+// apiTokenTestProvider serves valohai_api_token against mock provider data,
+// so Open and Close run through the plugin protocol with real private data.
+type apiTokenTestProvider struct {
+	meta map[string]interface{}
+}
+
+func (p *apiTokenTestProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "valohai"
+}
+
+func (p *apiTokenTestProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {
+}
+
+func (p *apiTokenTestProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	resp.EphemeralResourceData = p.meta
+}
+
+func (p *apiTokenTestProvider) Resources(context.Context) []func() resource.Resource { return nil }
+
+func (p *apiTokenTestProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *apiTokenTestProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{valohai.APITokenEphemeralResource}
+}
+
+// tokenAPI records the token requests and answers them like the tokens endpoint.
+func tokenAPI(t *testing.T, requests *[]string, created *map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPost:
+			json.NewDecoder(r.Body).Decode(created)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "tok1", "token": "minted-secret", "expires_at": "2026-10-19T13:00:00Z"})
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestAPITokenOpenAndClose(t *testing.T) {
+	var requests []string
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, tokenAPI(t, &requests, &created))
+	server := providerserver.NewProtocol5(&apiTokenTestProvider{meta: meta})()
+	ctx := context.Background()
+
+	emptyConfig, _ := tfprotov5.NewDynamicValue(tftypes.Object{}, tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{}))
+	if _, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &emptyConfig}); err != nil {
+		t.Fatal(err)
+	}
+
+	var schemaResp ephemeral.SchemaResponse
+	valohai.APITokenEphemeralResource().Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx)
+	config, err := tfprotov5.NewDynamicValue(typ, frameworkValue(t, typ, map[string]interface{}{"name": "ci", "ttl": "30m"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	openResp, err := server.OpenEphemeralResource(ctx, &tfprotov5.OpenEphemeralResourceRequest{TypeName: "valohai_api_token", Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range openResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	if len(requests) != 1 || requests[0] != "POST /api/v0/tokens/" || created["name"] != "ci" {
+		t.Fatalf("expected one POST creating the token, got %v (%v)", requests, created)
+	}
+
+	closeResp, err := server.CloseEphemeralResource(ctx, &tfprotov5.CloseEphemeralResourceRequest{TypeName: "valohai_api_token", Private: openResp.Private})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range closeResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	if len(requests) != 2 || requests[1] != "DELETE /api/v0/tokens/tok1/" {
+		t.Fatalf("expected Close to revoke the token from private data, got %v", requests)
+	}
+}
+
+func TestAPITokenRevokedWhenOpenFails(t *testing.T) {
+	var requests []string
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, tokenAPI(t, &requests, &created))
+	r := valohai.APITokenEphemeralResource()
+	ctx := context.Background()
+
+	var configureResp ephemeral.ConfigureResponse
+	r.(ephemeral.EphemeralResourceWithConfigure).Configure(ctx, ephemeral.ConfigureRequest{ProviderData: meta}, &configureResp)
+	var schemaResp ephemeral.SchemaResponse
+	r.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+	typ := s.Type().TerraformType(ctx)
+
+	// Private is left uninitialized, so storing the token id after the POST fails
+	resp := ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: s, Raw: tftypes.NewValue(typ, nil)}}
+	r.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, map[string]interface{}{})}}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected Open to fail")
+	}
+	if len(requests) != 2 || requests[1] != "DELETE /api/v0/tokens/tok1/" {
+		t.Fatalf("expected the minted token to be revoked, got %v", requests)
+	}
+}
@@ -0,0 +1,219 @@
+package tests
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+// unknown marks an attribute as unknown in the values passed to frameworkValue.
+var unknown = struct{}{}
+
+// frameworkValue builds a value of typ from plain Go values: nil is null,
+// unknown is unknown, maps and slices are converted recursively.
+func frameworkValue(t *testing.T, typ tftypes.Type, v interface{}) tftypes.Value {
+	t.Helper()
+	if v == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	if v == unknown {
+		return tftypes.NewValue(typ, tftypes.UnknownValue)
+	}
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		values, _ := v.(map[string]interface{})
+		attrs := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+		for name, attrType := range typ.AttributeTypes {
+			attrs[name] = frameworkValue(t, attrType, values[name])
+		}
+		return tftypes.NewValue(typ, attrs)
+	case tftypes.List:
+		return tftypes.NewValue(typ, frameworkElems(t, typ.ElementType, v))
+	case tftypes.Set:
+		return tftypes.NewValue(typ, frameworkElems(t, typ.ElementType, v))
+	case tftypes.Map:
+		values := v.(map[string]interface{})
+		elems := make(map[string]tftypes.Value, len(values))
+		for k, e := range values {
+			elems[k] = frameworkValue(t, typ.ElementType, e)
+		}
+		return tftypes.NewValue(typ, elems)
+	}
+	switch n := v.(type) {
+	case int:
+		return tftypes.NewValue(typ, big.NewFloat(float64(n)))
+	case float64:
+		return tftypes.NewValue(typ, big.NewFloat(n))
+	}
+	return tftypes.NewValue(typ, v)
+}
+
+func frameworkElems(t *testing.T, typ tftypes.Type, v interface{}) []tftypes.Value {
+	items := v.([]interface{})
+	elems := make([]tftypes.Value, 0, len(items))
+	for _, e := range items {
+		elems = append(elems, frameworkValue(t, typ, e))
+	}
+	return elems
+}
+
+// frameworkResource configures r with meta and returns its schema.
+func frameworkResource(t *testing.T, r resource.Resource, meta map[string]interface{}) resource.SchemaResponse {
+	t.Helper()
+	if c, ok := r.(resource.ResourceWithConfigure); ok {
+		var resp resource.ConfigureResponse
+		c.Configure(context.Background(), resource.ConfigureRequest{ProviderData: meta}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("configure failed: %v", resp.Diagnostics)
+		}
+	}
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	return resp
+}
+
+// frameworkCreate runs Create with a plan built from values and returns the new state.
+func frameworkCreate(t *testing.T, r resource.Resource, meta map[string]interface{}, values map[string]interface{}) (tfsdk.State, resource.CreateResponse) {
+	t.Helper()
+	s := frameworkResource(t, r, meta).Schema
+	typ := s.Type().TerraformType(context.Background())
+	req := resource.CreateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, values)},
+		Plan:   tfsdk.Plan{Schema: s, Raw: frameworkValue(t, typ, values)},
+	}
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(typ, nil)}}
+	r.Create(context.Background(), req, &resp)
+	return resp.State, resp
+}
+
+// frameworkRead runs Read on a state built from values and returns the refreshed state.
+func frameworkRead(t *testing.T, r resource.Resource, meta map[string]interface{}, values map[string]interface{}) (tfsdk.State, resource.ReadResponse) {
+	t.Helper()
+	s := frameworkResource(t, r, meta).Schema
+	typ := s.Type().TerraformType(context.Background())
+	state := tfsdk.State{Schema: s, Raw: frameworkValue(t, typ, values)}
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	return resp.State, resp
+}
+
+// frameworkUpdate runs Update from the prior state to the plan and returns the new state.
+func frameworkUpdate(t *testing.T, r resource.Resource, meta map[string]interface{}, prior, planned map[string]interface{}) (tfsdk.State, resource.UpdateResponse) {
+	t.Helper()
+	s := frameworkResource(t, r, meta).Schema
+	typ := s.Type().TerraformType(context.Background())
+	req := resource.UpdateRequest{
+		Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, planned)},
+		Plan:   tfsdk.Plan{Schema: s, Raw: frameworkValue(t, typ, planned)},
+		State:  tfsdk.State{Schema: s, Raw: frameworkValue(t, typ, prior)},
+	}
+	resp := resource.UpdateResponse{State: req.State}
+	r.Update(context.Background(), req, &resp)
+	return resp.State, resp
+}
+
+// frameworkDelete runs Delete on a state built from values.
+func frameworkDelete(t *testing.T, r resource.Resource, meta map[string]interface{}, values map[string]interface{}) resource.DeleteResponse {
+	t.Helper()
+	s := frameworkResource(t, r, meta).Schema
+	typ := s.Type().TerraformType(context.Background())
+	state := tfsdk.State{Schema: s, Raw: frameworkValue(t, typ, values)}
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+	return resp
+}
+
+// frameworkDataSourceRead configures d with meta and reads it with a config built from values.
+func frameworkDataSourceRead(t *testing.T, d datasource.DataSource, meta map[string]interface{}, values map[string]interface{}) (tfsdk.State, datasource.ReadResponse) {
+	t.Helper()
+	if c, ok := d.(datasource.DataSourceWithConfigure); ok {
+		var resp datasource.ConfigureResponse
+		c.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: meta}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("configure failed: %v", resp.Diagnostics)
+		}
+	}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+	typ := s.Type().TerraformType(context.Background())
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(typ, nil)}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, values)}}, &resp)
+	return resp.State, resp
+}
+
+// stateString returns a top-level string attribute of state, or "" when null.
+func stateString(t *testing.T, state tfsdk.State, attr string) string {
+	t.Helper()
+	var v *string
+	if diags := state.GetAttribute(context.Background(), path.Root(attr), &v); diags.HasError() {
+		t.Fatalf("failed to read %s: %v", attr, diags)
+	}
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// stateBool returns a top-level bool attribute of state, or false when null.
+func stateBool(t *testing.T, state tfsdk.State, attr string) bool {
+	t.Helper()
+	var v *bool
+	if diags := state.GetAttribute(context.Background(), path.Root(attr), &v); diags.HasError() {
+		t.Fatalf("failed to read %s: %v", attr, diags)
+	}
+	return v != nil && *v
+}
+
+// frameworkPlan plans a change of typeName through the provider server, so
+// defaults and plan modifiers run the way Terraform runs them. Attributes left
+// out of config take their prior value in the proposed state, as in Terraform.
+func frameworkPlan(t *testing.T, typeName string, r resource.Resource, prior, config map[string]interface{}) *tfprotov5.PlanResourceChangeResponse {
+	t.Helper()
+	ctx := context.Background()
+	typ := frameworkResource(t, r, nil).Schema.Type().TerraformType(ctx)
+	proposed := make(map[string]interface{}, len(prior))
+	for k, v := range prior {
+		proposed[k] = v
+	}
+	for k, v := range config {
+		if v != nil {
+			proposed[k] = v
+		}
+	}
+
+	dynamic := func(values map[string]interface{}) *tfprotov5.DynamicValue {
+		v, err := tfprotov5.NewDynamicValue(typ, frameworkValue(t, typ, values))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &v
+	}
+	server, err := valohai.ProviderServer(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server().PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       dynamic(prior),
+		ProposedNewState: dynamic(proposed),
+		Config:           dynamic(config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected plan error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	return resp
+}
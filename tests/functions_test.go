@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+func TestParseProjectSlug(t *testing.T) {
+	owner, project, err := valohai.ParseProjectSlug("my-org/my-project")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner != "my-org" || project != "my-project" {
+		t.Fatalf("unexpected result: %q, %q", owner, project)
+	}
+
+	for _, slug := range []string{"", "my-org", "my-org/", "/my-project", "a/b/c"} {
+		if _, _, err := valohai.ParseProjectSlug(slug); err == nil {
+			t.Errorf("expected error for slug %q", slug)
+		}
+	}
+}
+
+func TestDatumURI(t *testing.T) {
+	cases := map[string]string{
+		"017f1d3c-8e0a-4b3e-9d4e-3f1a2b3c4d5e": "datum://017f1d3c-8e0a-4b3e-9d4e-3f1a2b3c4d5e",
+		"prod-training-set":                    "datum://prod-training-set",
+		"datum://prod-training-set":            "datum://prod-training-set",
+	}
+	for in, want := range cases {
+		got, err := valohai.DatumURI(in)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", in, err)
+		}
+		if got != want {
+			t.Errorf("DatumURI(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "datum://", "a/b", "with space"} {
+		if _, err := valohai.DatumURI(in); err == nil {
+			t.Errorf("expected error for %q", in)
+		}
+	}
+}
+
+func TestImageMatchesPattern(t *testing.T) {
+	cases := []struct {
+		image, pattern string
+		want           bool
+	}{
+		{"docker.io/library/python:3.12", "docker.io/*", true},
+		{"123456789012.dkr.ecr.eu-west-1.amazonaws.com/team/app:1", "123456789012.dkr.ecr.eu-west-1.amazonaws.com/*", true},
+		{"gcr.io/project/image", "docker.io/*", false},
+		{"docker.io/app:v1", "docker.io/app:v?", true},
+		{"dockerxio/app", "docker.io/*", false},
+	}
+	for _, c := range cases {
+		if got := valohai.ImageMatchesPattern(c.image, c.pattern); got != c.want {
+			t.Errorf("ImageMatchesPattern(%q, %q) = %v, want %v", c.image, c.pattern, got, c.want)
+		}
+	}
+}
+
+func TestStoreURI(t *testing.T) {
+	cases := []struct {
+		typ, bucket, path, want string
+	}{
+		{"s3", "my-bucket", "data/input", "s3://my-bucket/data/input"},
+		{"google", "my-bucket", "/data/", "gs://my-bucket/data"},
+		{"azure", "container", "", "azure://container"},
+		{"swift", "container", "x", "swift://container/x"},
+	}
+	for _, c := range cases {
+		got, err := valohai.StoreURI(c.typ, c.bucket, c.path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != c.want {
+			t.Errorf("StoreURI(%q, %q, %q) = %q, want %q", c.typ, c.bucket, c.path, got, c.want)
+		}
+	}
+
+	if _, err := valohai.StoreURI("ftp", "bucket", ""); err == nil {
+		t.Error("expected error for unsupported store type")
+	}
+	if _, err := valohai.StoreURI("s3", "", "path"); err == nil {
+		t.Error("expected error for empty bucket")
+	}
+}
+
+func TestParseProjectSlugFunctionThroughProviderServer(t *testing.T) {
+	server, err := valohai.ProviderServer(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("failed to create provider server: %v", err)
+	}
+	arg, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, "my-org/my-project"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server().CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{
+		Name:      "parse_project_slug",
+		Arguments: []*tfprotov5.DynamicValue{&arg},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Error != nil {
+		t.Fatalf("unexpected function error: %s", resp.Error.Text)
+	}
+
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"owner": tftypes.String, "project": tftypes.String}}
+	val, err := resp.Result.Unmarshal(objType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attrs map[string]tftypes.Value
+	if err := val.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	var owner string
+	if err := attrs["owner"].As(&owner); err != nil || owner != "my-org" {
+		t.Fatalf("expected owner my-org, got %q (%v)", owner, err)
+	}
+}
+
+func TestStoreURIFunctionArgumentErrors(t *testing.T) {
+	server, err := valohai.ProviderServer(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("failed to create provider server: %v", err)
+	}
+	cases := []struct {
+		typ, bucket string
+		arg         int64
+	}{
+		{"ftp", "bucket", 0},
+		{"s3", " / ", 1},
+	}
+	for _, c := range cases {
+		var args []*tfprotov5.DynamicValue
+		for _, v := range []string{c.typ, c.bucket, ""} {
+			dv, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, v))
+			if err != nil {
+				t.Fatal(err)
+			}
+			args = append(args, &dv)
+		}
+		resp, err := server().CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{Name: "store_uri", Arguments: args})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != c.arg {
+			t.Errorf("store_uri(%q, %q): expected an error on argument %d, got %+v", c.typ, c.bucket, c.arg, resp.Error)
+		}
+	}
+}
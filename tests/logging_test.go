@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+func TestRedactJSON(t *testing.T) {
+	body := []byte(`{"name":"store","configuration":{"access_key_id":"AKIA","secret_access_key":"s3cr3t"},"items":[{"password":"hunter2"}]}`)
+	got := valohai.RedactJSON(body)
+	for _, secret := range []string{"s3cr3t", "hunter2"} {
+		if strings.Contains(got, secret) {
+			t.Fatalf("expected %q to be redacted, got %s", secret, got)
+		}
+	}
+	if !strings.Contains(got, `"access_key_id":"AKIA"`) {
+		t.Fatalf("expected non-secret fields to be kept, got %s", got)
+	}
+
+	body = []byte(`{"title":"webhook","conditions":[{"type":"web-request-auth","auth_type":"static-secret","secret":"wh-s3cr3t"}]}`)
+	got = valohai.RedactJSON(body)
+	if strings.Contains(got, "wh-s3cr3t") {
+		t.Fatalf("expected the webhook secret to be redacted, got %s", got)
+	}
+	if !strings.Contains(got, `"auth_type":"static-secret"`) {
+		t.Fatalf("expected non-secret condition fields to be kept, got %s", got)
+	}
+
+	body = []byte(`{"kind":"slack","config":{"url":"https://hooks.slack.com/services/T0/B0/xoxb"},"url":"https://app.valohai.com/api/v0/notification-channels/c1/"}`)
+	got = valohai.RedactJSON(body)
+	if strings.Contains(got, "hooks.slack.com") {
+		t.Fatalf("expected the channel URL to be redacted, got %s", got)
+	}
+	if !strings.Contains(got, "/api/v0/notification-channels/c1/") {
+		t.Fatalf("expected top-level API links to be kept, got %s", got)
+	}
+
+	if got := valohai.RedactJSON([]byte("not json")); got != "not json" {
+		t.Fatalf("expected non-JSON body to be returned unchanged, got %s", got)
+	}
+}
+
+// debugLogLevel sets the provider log level the way Terraform would with TF_LOG=level.
+func debugLogLevel(t *testing.T, level string) {
+	t.Setenv("TF_LOG_PROVIDER_VALOHAI", "")
+	t.Setenv("TF_LOG_PROVIDER", "")
+	t.Setenv("TF_LOG", level)
+}
+
+func TestHTTPClientLogsRequests(t *testing.T) {
+	debugLogLevel(t, "DEBUG")
+	var sentRequestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sentRequestID = r.Header.Get("X-Request-Id")
+		w.Header().Set("X-Request-Id", "srv-req-42")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"abc","configuration":{"password":"hunter2"}}`))
+	}))
+	defer server.Close()
+
+	client := configuredHTTPClient(t, map[string]interface{}{})
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	req, err := http.NewRequestWithContext(ctx, "POST", server.URL+"/api/v0/stores/", strings.NewReader(`{"secret_access_key":"s3cr3t"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Token super-secret-token")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	out := logs.String()
+	if sentRequestID != "" {
+		t.Errorf("expected no client-generated request id, got %q", sentRequestID)
+	}
+	for _, want := range []string{`"method":"POST"`, `"status":201`, "/api/v0/stores/", `"request_id":"srv-req-42"`, "latency_ms", `"User-Agent":"terraform-provider-valohai/`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected logs to contain %s, got:\n%s", want, out)
+		}
+	}
+	for _, secret := range []string{"super-secret-token", "s3cr3t", "hunter2"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected %q to be redacted from logs, got:\n%s", secret, out)
+		}
+	}
+}
+
+func TestHTTPClientSkipsLoggingBelowDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"abc"}`))
+	}))
+	defer server.Close()
+
+	client := configuredHTTPClient(t, map[string]interface{}{})
+
+	for _, level := range []string{"", "INFO"} {
+		debugLogLevel(t, level)
+		var logs bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &logs)
+		req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v0/stores/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil || string(body) != `{"id":"abc"}` {
+			t.Fatalf("expected the response body to be readable, got %q (%v)", body, err)
+		}
+		if logs.Len() != 0 {
+			t.Errorf("TF_LOG=%q: expected no request logs, got:\n%s", level, logs.String())
+		}
+	}
+}
+
+func TestHTTPClientRedactsNotificationChannelURL(t *testing.T) {
+	debugLogLevel(t, "TRACE")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"c1","kind":"webhook","config":{"url":"https://alerts.example.com/hook?token=tok-from-api"}}`))
+	}))
+	defer server.Close()
+
+	client := configuredHTTPClient(t, map[string]interface{}{})
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	body := `{"name":"alerts","kind":"slack","config":{"url":"https://hooks.slack.com/services/T0/B0/xoxb-secret"}}`
+	req, err := http.NewRequestWithContext(ctx, "POST", server.URL+"/api/v0/notification-channels/", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	out := logs.String()
+	if !strings.Contains(out, "/api/v0/notification-channels/") {
+		t.Fatalf("expected the request to be logged, got:\n%s", out)
+	}
+	for _, secret := range []string{"xoxb-secret", "tok-from-api"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected %q to be redacted from logs, got:\n%s", secret, out)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+// newPaginatedServer serves `pages` pages of two items each on /items/.
+func newPaginatedServer(t *testing.T, pages int, requests *[]string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+		page := 1
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		var next interface{}
+		if page < pages {
+			q := r.URL.Query()
+			q.Set("page", fmt.Sprint(page+1))
+			next = server.URL + "/items/?" + q.Encode()
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":    pages * 2,
+			"next":     next,
+			"previous": nil,
+			"results": []map[string]interface{}{
+				{"id": fmt.Sprintf("%d-a", page)},
+				{"id": fmt.Sprintf("%d-b", page)},
+			},
+		})
+	}))
+	return server
+}
+
+func testMeta() map[string]interface{} {
+	return map[string]interface{}{"token": "test-token", "http_client": http.DefaultClient}
+}
+
+func TestListEachFollowsNextLinks(t *testing.T) {
+	var requests []string
+	server := newPaginatedServer(t, 3, &requests)
+	defer server.Close()
+
+	var ids []string
+	err := valohai.ListEach(context.Background(), testMeta(), server.URL+"/items/", valohai.ListOptions{
+		Filters:  map[string]string{"owner": "42"},
+		Ordering: "-ctime",
+	}, func(item json.RawMessage) (bool, error) {
+		var v struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(item, &v); err != nil {
+			return false, err
+		}
+		ids = append(ids, v.ID)
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(ids, ","); got != "1-a,1-b,2-a,2-b,3-a,3-b" {
+		t.Fatalf("unexpected items: %s", got)
+	}
+	if !strings.Contains(requests[0], "owner=42") || !strings.Contains(requests[0], "ordering=-ctime") {
+		t.Fatalf("expected filters and ordering in query, got %s", requests[0])
+	}
+}
+
+func TestListEachStopsEarly(t *testing.T) {
+	var requests []string
+	server := newPaginatedServer(t, 5, &requests)
+	defer server.Close()
+
+	err := valohai.ListEach(context.Background(), testMeta(), server.URL+"/items/", valohai.ListOptions{},
+		func(item json.RawMessage) (bool, error) {
+			return strings.Contains(string(item), "2-a"), nil
+		})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected iteration to stop after 2 pages, fetched %d", len(requests))
+	}
+}
+
+func TestListEachMaxPages(t *testing.T) {
+	var requests []string
+	server := newPaginatedServer(t, 5, &requests)
+	defer server.Close()
+
+	err := valohai.ListEach(context.Background(), testMeta(), server.URL+"/items/", valohai.ListOptions{MaxPages: 2},
+		func(item json.RawMessage) (bool, error) { return false, nil })
+	if err == nil || !strings.Contains(err.Error(), "exceeded 2 pages") {
+		t.Fatalf("expected max pages error, got %v", err)
+	}
+}
+
+func TestListEachDetectsLoops(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":   1,
+			"next":    server.URL + "/items/",
+			"results": []interface{}{},
+		})
+	}))
+	defer server.Close()
+
+	err := valohai.ListEach(context.Background(), testMeta(), server.URL+"/items/", valohai.ListOptions{},
+		func(item json.RawMessage) (bool, error) { return false, nil })
+	if err == nil || !strings.Contains(err.Error(), "pagination loop") {
+		t.Fatalf("expected loop error, got %v", err)
+	}
+}
+
+func TestListEachRefusesSchemeDowngrade(t *testing.T) {
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":   2,
+			"next":    "http://" + r.Host + "/items/?page=2",
+			"results": []interface{}{map[string]interface{}{"id": "1-a"}},
+		})
+	}))
+	defer server.Close()
+
+	meta := map[string]interface{}{"token": "test-token", "http_client": server.Client()}
+	err := valohai.ListEach(context.Background(), meta, server.URL+"/items/", valohai.ListOptions{},
+		func(item json.RawMessage) (bool, error) { return false, nil })
+	if err == nil || !strings.Contains(err.Error(), "from https to http") {
+		t.Fatalf("expected the downgraded next link to be refused, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected only the first page to be requested, got %d requests", requests)
+	}
+}
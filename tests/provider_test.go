@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tacy-ops/terraform-provider-valohai/valohai"
 )
@@ -32,3 +33,68 @@ func TestProviderConfigureMissingToken(t *testing.T) {
 		t.Fatalf("unexpected error: %s", diags[0].Summary)
 	}
 }
+
+func TestProviderConfigureHTTPClient(t *testing.T) {
+	provider := valohai.Provider()
+	data := schema.TestResourceDataRaw(t, provider.Schema, map[string]interface{}{
+		"token":                "test-token",
+		"proxy_url":            "http://proxy.example.com:3128",
+		"insecure_skip_verify": true,
+	})
+	meta, diags := provider.ConfigureContextFunc(context.Background(), data)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, ok := meta.(map[string]interface{})["http_client"]; !ok {
+		t.Fatal("expected meta to include the shared http_client")
+	}
+}
+
+func TestProviderConfigureInvalidProxyURL(t *testing.T) {
+	provider := valohai.Provider()
+	data := schema.TestResourceDataRaw(t, provider.Schema, map[string]interface{}{
+		"token":     "test-token",
+		"proxy_url": "proxy.example.com",
+	})
+	_, diags := provider.ConfigureContextFunc(context.Background(), data)
+	if !diags.HasError() {
+		t.Fatal("expected error for proxy_url without scheme")
+	}
+	if !strings.Contains(diags[0].Summary, "invalid proxy_url") {
+		t.Fatalf("unexpected error: %s", diags[0].Summary)
+	}
+}
+
+func TestProviderConfigureInvalidCACert(t *testing.T) {
+	provider := valohai.Provider()
+	data := schema.TestResourceDataRaw(t, provider.Schema, map[string]interface{}{
+		"token":       "test-token",
+		"ca_cert_pem": "not a certificate",
+	})
+	_, diags := provider.ConfigureContextFunc(context.Background(), data)
+	if !diags.HasError() {
+		t.Fatal("expected error for invalid ca_cert_pem")
+	}
+	if !strings.Contains(diags[0].Summary, "no valid PEM certificate") {
+		t.Fatalf("unexpected error: %s", diags[0].Summary)
+	}
+}
+
+func TestProviderServerSchema(t *testing.T) {
+	server, err := valohai.ProviderServer(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("failed to create provider server: %v", err)
+	}
+	resp, err := server().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	if _, ok := resp.ResourceSchemas["valohai_project"]; !ok {
+		t.Fatal("expected SDK resources to be served through the mux server")
+	}
+}
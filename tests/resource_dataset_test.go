@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+func TestDatasetCreateAndRead(t *testing.T) {
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "ds1"})
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "ds1", "project": map[string]interface{}{"id": "p1"}, "name": "training", "access_mode": "organization"})
+		}
+	})
+	values := map[string]interface{}{"id": unknown, "project": "p1", "name": "training", "access": "project", "uri": unknown}
+	state, resp := frameworkCreate(t, valohai.DatasetResource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if created["access_mode"] != "project" || created["name"] != "training" {
+		t.Errorf("unexpected create payload %v", created)
+	}
+	if got := stateString(t, state, "uri"); got != "dataset://training" {
+		t.Errorf("expected uri dataset://training, got %q", got)
+	}
+
+	values["id"] = "ds1"
+	state, readResp := frameworkRead(t, valohai.DatasetResource(), meta, values)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", readResp.Diagnostics)
+	}
+	if got := stateString(t, state, "access"); got != "organization" {
+		t.Errorf("expected access drift to organization, got %q", got)
+	}
+}
+
+func TestDatasetVersionCreate(t *testing.T) {
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&created)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "dv1", "name": "v1", "dataset": map[string]interface{}{"id": "ds1", "name": "training"}})
+	})
+	state, resp := frameworkCreate(t, valohai.DatasetVersionResource(), meta, map[string]interface{}{
+		"id":      unknown,
+		"dataset": "ds1",
+		"name":    "v1",
+		"datums":  []interface{}{"datum://d1", "d2"},
+		"uri":     unknown,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	files := created["files"].([]interface{})
+	if len(files) != 2 || files[0].(map[string]interface{})["datum"] != "d1" || files[1].(map[string]interface{})["datum"] != "d2" {
+		t.Errorf("expected datum ids in the payload, got %v", files)
+	}
+	if got := stateString(t, state, "uri"); got != "dataset://training/v1" {
+		t.Errorf("expected uri dataset://training/v1, got %q", got)
+	}
+}
+
+func TestDatasetVersionReadDrift(t *testing.T) {
+	datums := []string{"d2", "d1"}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		files := []interface{}{}
+		for _, d := range datums {
+			files = append(files, map[string]interface{}{"datum": map[string]interface{}{"id": d}})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "dv1", "name": "v1", "dataset": map[string]interface{}{"id": "ds1", "name": "training"}, "files": files})
+	})
+	values := map[string]interface{}{"id": "dv1", "dataset": "ds1", "name": "v1", "datums": []interface{}{"datum://d1", "d2"}}
+
+	// Same datums in another order and form: the configured list is kept
+	state, resp := frameworkRead(t, valohai.DatasetVersionResource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	var got []string
+	state.GetAttribute(context.Background(), path.Root("datums"), &got)
+	if len(got) != 2 || got[0] != "datum://d1" {
+		t.Errorf("expected the configured datums to be kept, got %v", got)
+	}
+
+	datums = []string{"d1", "d3"}
+	state, resp = frameworkRead(t, valohai.DatasetVersionResource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	state.GetAttribute(context.Background(), path.Root("datums"), &got)
+	if len(got) != 2 || got[1] != "d3" {
+		t.Errorf("expected the datums of the API to show as drift, got %v", got)
+	}
+}
+
+func TestDatasetVersionValidateConfig(t *testing.T) {
+	r := valohai.DatasetVersionResource()
+	s := frameworkResource(t, r, nil).Schema
+	typ := s.Type().TerraformType(context.Background())
+
+	for name, datums := range map[string][]interface{}{
+		"empty":   {},
+		"invalid": {"datum://bad/id"},
+		"alias":   {"datum://prod-training-set"},
+	} {
+		var resp resource.ValidateConfigResponse
+		r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, map[string]interface{}{"dataset": "ds1", "name": "v1", "datums": datums})},
+		}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+
+	var resp resource.ValidateConfigResponse
+	datums := []interface{}{"0184b8f5-aaaa-4bbb-8ccc-ddddeeeeffff", "datum://0184b8f5-1111-4222-8333-444455556666"}
+	r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, map[string]interface{}{"dataset": "ds1", "name": "v1", "datums": datums})},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("expected datum ids and id URIs to be accepted, got %v", resp.Diagnostics)
+	}
+}
+
+func TestDatasetVersionReadBareDatasetID(t *testing.T) {
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "dv1", "name": "v1", "dataset": "ds1", "files": []interface{}{map[string]interface{}{"datum": "d1"}}})
+	})
+	values := map[string]interface{}{"id": "dv1", "dataset": "ds1", "name": "v1", "datums": []interface{}{"d1"}, "uri": "dataset://training/v1"}
+	state, resp := frameworkRead(t, valohai.DatasetVersionResource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if got := stateString(t, state, "dataset"); got != "ds1" {
+		t.Errorf("expected dataset ds1, got %q", got)
+	}
+	if got := stateString(t, state, "uri"); got != "dataset://training/v1" {
+		t.Errorf("expected the uri in state to be kept, got %q", got)
+	}
+}
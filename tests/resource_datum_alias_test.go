@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+func TestDatumAliasDrift(t *testing.T) {
+	datum := "d1"
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "a1", "project": "p1", "name": "prod-training-set", "datum": map[string]interface{}{"id": datum}})
+	})
+	values := map[string]interface{}{"id": "a1", "project": "p1", "name": "prod-training-set", "datum": "datum://d1"}
+
+	state, resp := frameworkRead(t, valohai.DatumAliasResource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if got := stateString(t, state, "datum"); got != "datum://d1" {
+		t.Errorf("expected the configured URI to be kept, got %q", got)
+	}
+	if got := stateString(t, state, "uri"); got != "datum://prod-training-set" {
+		t.Errorf("expected uri datum://prod-training-set, got %q", got)
+	}
+
+	// Repointed by hand in the UI
+	datum = "d2"
+	state, resp = frameworkRead(t, valohai.DatumAliasResource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if got := stateString(t, state, "datum"); got != "d2" {
+		t.Errorf("expected drift to d2, got %q", got)
+	}
+}
+
+func TestDatumAliasRepoint(t *testing.T) {
+	var patched map[string]interface{}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v0/datum-aliases/a1/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&patched)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "a1"})
+	})
+	prior := map[string]interface{}{"id": "a1", "project": "p1", "name": "prod-training-set", "datum": "d1", "uri": "datum://prod-training-set"}
+	planned := map[string]interface{}{"id": "a1", "project": "p1", "name": "prod-training-set", "datum": "datum://d2", "uri": "datum://prod-training-set"}
+	_, resp := frameworkUpdate(t, valohai.DatumAliasResource(), meta, prior, planned)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if len(patched) != 1 || patched["datum"] != "d2" {
+		t.Errorf("expected only the datum id to be sent, got %v", patched)
+	}
+}
+
+func TestDatumAliasValidateConfig(t *testing.T) {
+	r := valohai.DatumAliasResource()
+	s := frameworkResource(t, r, nil).Schema
+	typ := s.Type().TerraformType(context.Background())
+
+	for name, values := range map[string]map[string]interface{}{
+		"prefixed name": {"project": "p1", "name": "datum://prod", "datum": "d1"},
+		"slash in name": {"project": "p1", "name": "prod/v1", "datum": "d1"},
+		"bad datum":     {"project": "p1", "name": "prod", "datum": "datum://"},
+		"alias datum":   {"project": "p1", "name": "prod", "datum": "datum://other-alias"},
+	} {
+		var resp resource.ValidateConfigResponse
+		r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, values)},
+		}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+
+	var resp resource.ValidateConfigResponse
+	values := map[string]interface{}{"project": "p1", "name": "prod", "datum": "datum://0184b8f5-1111-2222-3333-444455556666"}
+	r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, values)},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Errorf("expected a datum id URI to be accepted, got %v", resp.Diagnostics)
+	}
+}
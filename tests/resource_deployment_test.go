@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+const deploymentID = "0184b8f5-dddd-2222-3333-444455556666"
+
+func deploymentAPI(t *testing.T, created *map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deployment := map[string]interface{}{
+			"id":      deploymentID,
+			"name":    "predict",
+			"project": map[string]interface{}{"id": "p1", "name": "demo"},
+			"target":  map[string]interface{}{"id": "t1", "name": "k8s-prod"},
+			"url":     "https://app.valohai.com/api/v0/deployments/" + deploymentID + "/",
+			"aliases": []interface{}{map[string]interface{}{"name": "production"}},
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v0/deployments/":
+			json.NewDecoder(r.Body).Decode(created)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(deployment)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v0/deployments/"+deploymentID+"/":
+			json.NewEncoder(w).Encode(deployment)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestDeploymentCreate(t *testing.T) {
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, deploymentAPI(t, &created))
+	state, resp := frameworkCreate(t, valohai.DeploymentResource(), meta, map[string]interface{}{
+		"id":      unknown,
+		"project": "p1",
+		"name":    "predict",
+		"target":  "t1",
+		"url":     unknown,
+		"aliases": unknown,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if created["project"] != "p1" || created["name"] != "predict" || created["target"] != "t1" {
+		t.Errorf("unexpected create payload %v", created)
+	}
+	if got := stateString(t, state, "id"); got != deploymentID {
+		t.Errorf("expected id %s, got %q", deploymentID, got)
+	}
+	var aliases []string
+	state.GetAttribute(context.Background(), path.Root("aliases"), &aliases)
+	if len(aliases) != 1 || aliases[0] != "production" {
+		t.Errorf("expected the production alias, got %v", aliases)
+	}
+}
+
+func TestDeploymentImport(t *testing.T) {
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, deploymentAPI(t, &created))
+	r := valohai.DeploymentResource()
+	s := frameworkResource(t, r, meta).Schema
+
+	importResp := resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: frameworkValue(t, s.Type().TerraformType(context.Background()), nil)}}
+	r.(resource.ResourceWithImportState).ImportState(context.Background(), resource.ImportStateRequest{ID: deploymentID}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", importResp.Diagnostics)
+	}
+
+	// Read fills the arguments from the API after import
+	state, resp := frameworkRead(t, r, meta, map[string]interface{}{"id": deploymentID})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	for attr, want := range map[string]string{"project": "p1", "target": "t1", "name": "predict"} {
+		if got := stateString(t, state, attr); got != want {
+			t.Errorf("expected %s = %q, got %q", attr, want, got)
+		}
+	}
+}
+
+func TestDeploymentReadRemoved(t *testing.T) {
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	state, resp := frameworkRead(t, valohai.DeploymentResource(), meta, map[string]interface{}{"id": deploymentID, "project": "p1", "name": "predict", "target": "t1"})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !state.Raw.IsNull() {
+		t.Error("expected the deployment to be removed from state")
+	}
+}
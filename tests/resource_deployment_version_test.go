@@ -0,0 +1,241 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+const deploymentVersionID = "0184b8f5-eeee-2222-3333-444455556666"
+
+// deploymentVersionAPI serves a version whose rollout walks through statuses.
+func deploymentVersionAPI(t *testing.T, created *map[string]interface{}, statuses ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		version := map[string]interface{}{
+			"id":             deploymentVersionID,
+			"name":           "20261019.0",
+			"deployment":     deploymentID,
+			"enabled":        true,
+			"status":         statuses[0],
+			"status_message": "",
+			"url":            "https://app.valohai.com/api/v0/deployment-versions/" + deploymentVersionID + "/",
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v0/deployment-versions/":
+			json.NewDecoder(r.Body).Decode(created)
+			version["status"] = "pending"
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v0/deployment-versions/"+deploymentVersionID+"/":
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			if version["status"] == "crashed" {
+				version["status_message"] = "endpoint predict: CrashLoopBackOff"
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(version)
+	}
+}
+
+func deploymentVersionPlan() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         unknown,
+		"deployment": deploymentID,
+		"commit":     "7f3c2a1",
+		"name":       unknown,
+		"enabled":    true,
+		"status":     unknown,
+		"url":        unknown,
+		"endpoint": []interface{}{
+			map[string]interface{}{"name": "predict", "replicas": 2, "cpu": 0.5, "memory": 1024},
+		},
+	}
+}
+
+func TestDeploymentVersionCreateWaitsForRollout(t *testing.T) {
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, deploymentVersionAPI(t, &created, "deploying", "ready"))
+	state, resp := frameworkCreate(t, valohai.DeploymentVersionResource(), meta, deploymentVersionPlan())
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if got := stateString(t, state, "status"); got != "ready" {
+		t.Errorf("expected status ready, got %q", got)
+	}
+	if got := stateString(t, state, "name"); got != "20261019.0" {
+		t.Errorf("expected the generated name, got %q", got)
+	}
+
+	endpoint, _ := created["endpoint_configurations"].(map[string]interface{})["predict"].(map[string]interface{})
+	if endpoint["replicas"] != float64(2) {
+		t.Errorf("expected 2 replicas, got %v", endpoint["replicas"])
+	}
+	resources, _ := endpoint["resources"].(map[string]interface{})
+	if resources["cpu"].(map[string]interface{})["max"] != 0.5 || resources["memory"].(map[string]interface{})["max"] != float64(1024) {
+		t.Errorf("unexpected resource limits %v", resources)
+	}
+}
+
+func TestDeploymentVersionRolloutError(t *testing.T) {
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, deploymentVersionAPI(t, &created, "crashed"))
+	state, resp := frameworkCreate(t, valohai.DeploymentVersionResource(), meta, deploymentVersionPlan())
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the apply to fail on a crashed rollout")
+	}
+	if !strings.Contains(resp.Diagnostics[0].Detail(), "CrashLoopBackOff") {
+		t.Errorf("expected the status message in the error, got %v", resp.Diagnostics)
+	}
+	// The version stays in state so Terraform taints it rather than losing track of it
+	if got := stateString(t, state, "id"); got != deploymentVersionID {
+		t.Errorf("expected id %s in state, got %q", deploymentVersionID, got)
+	}
+}
+
+func TestDeploymentVersionPlanToggleEnabled(t *testing.T) {
+	prior := deploymentVersionPlan()
+	prior["id"], prior["name"], prior["status"], prior["url"] = deploymentVersionID, "20261019.0", "ready", ""
+	config := deploymentVersionPlan()
+	config["id"], config["name"], config["status"], config["url"] = nil, nil, nil, nil
+	config["enabled"] = false
+
+	resp := frameworkPlan(t, "valohai_deployment_version", valohai.DeploymentVersionResource(), prior, config)
+	if len(resp.RequiresReplace) > 0 {
+		t.Fatalf("expected disabling the version to update it in place, got replacement for %v", resp.RequiresReplace)
+	}
+}
+
+func TestDeploymentVersionValidateUnknownEndpoints(t *testing.T) {
+	r := valohai.DeploymentVersionResource()
+	s := frameworkResource(t, r, nil).Schema
+	typ := s.Type().TerraformType(context.Background())
+
+	// e.g. dynamic "endpoint" over a value only known after apply
+	values := deploymentVersionPlan()
+	values["endpoint"] = unknown
+	var resp resource.ValidateConfigResponse
+	r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, values)},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected validation error: %v", resp.Diagnostics)
+	}
+}
+
+func TestDeploymentVersionRolloutStopped(t *testing.T) {
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, deploymentVersionAPI(t, &created, "deploying", "stopped"))
+	_, resp := frameworkCreate(t, valohai.DeploymentVersionResource(), meta, deploymentVersionPlan())
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), `"stopped"`) {
+		t.Fatalf("expected the apply to stop polling on a stopped version, got %v", resp.Diagnostics)
+	}
+}
+
+func TestDeploymentVersionReadDetectsDrift(t *testing.T) {
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":         deploymentVersionID,
+			"name":       "20261019.0",
+			"deployment": map[string]interface{}{"id": deploymentID},
+			"commit":     map[string]interface{}{"identifier": "9e1d0b2"},
+			"enabled":    true,
+			"status":     "ready",
+			"endpoint_configurations": map[string]interface{}{
+				"predict": map[string]interface{}{
+					"enabled":   true,
+					"replicas":  3,
+					"resources": map[string]interface{}{"cpu": map[string]interface{}{"max": 0.5}, "memory": map[string]interface{}{"max": 2048}},
+				},
+				"explain": map[string]interface{}{"enabled": true, "replicas": 1},
+				"batch":   map[string]interface{}{"enabled": false, "replicas": 1},
+			},
+		})
+	})
+	prior := deploymentVersionPlan()
+	prior["id"], prior["name"], prior["status"], prior["url"] = deploymentVersionID, "20261019.0", "ready", ""
+	prior["endpoint"] = []interface{}{
+		map[string]interface{}{"name": "predict", "replicas": 2, "cpu": nil, "memory": 1024},
+	}
+
+	state, resp := frameworkRead(t, valohai.DeploymentVersionResource(), meta, prior)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if got := stateString(t, state, "commit"); got != "9e1d0b2" {
+		t.Errorf("expected the remote commit, got %q", got)
+	}
+
+	var endpoints []struct {
+		Name     types.String  `tfsdk:"name"`
+		Replicas types.Int64   `tfsdk:"replicas"`
+		CPU      types.Float64 `tfsdk:"cpu"`
+		Memory   types.Int64   `tfsdk:"memory"`
+	}
+	if diags := state.GetAttribute(context.Background(), path.Root("endpoint"), &endpoints); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(endpoints) != 2 || endpoints[0].Name.ValueString() != "predict" || endpoints[1].Name.ValueString() != "explain" {
+		t.Fatalf("expected the predict and explain endpoints, got %v", endpoints)
+	}
+	if endpoints[0].Replicas.ValueInt64() != 3 || endpoints[0].Memory.ValueInt64() != 2048 {
+		t.Errorf("expected the remote replicas and memory, got %v", endpoints[0])
+	}
+	if !endpoints[0].CPU.IsNull() {
+		t.Errorf("expected cpu left unset in state to stay null, got %v", endpoints[0].CPU)
+	}
+}
+
+func TestDeploymentAliasMove(t *testing.T) {
+	var patched map[string]interface{}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v0/deployment-versions/"):
+			status := "ready"
+			if strings.Contains(r.URL.Path, "broken") {
+				status = "failed"
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "v2", "status": status})
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v0/deployment-version-aliases/a1/":
+			json.NewDecoder(r.Body).Decode(&patched)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "a1"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	prior := map[string]interface{}{"id": "a1", "deployment": deploymentID, "name": "production", "version": "v1"}
+
+	planned := map[string]interface{}{"id": "a1", "deployment": deploymentID, "name": "production", "version": "v2"}
+	state, resp := frameworkUpdate(t, valohai.DeploymentAliasResource(), meta, prior, planned)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if patched["target"] != "v2" {
+		t.Errorf("expected the alias to move to v2, got %v", patched)
+	}
+	if got := stateString(t, state, "version"); got != "v2" {
+		t.Errorf("expected version v2 in state, got %q", got)
+	}
+
+	patched = nil
+	planned["version"] = "broken"
+	_, resp = frameworkUpdate(t, valohai.DeploymentAliasResource(), meta, prior, planned)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected moving the alias to a failed version to fail")
+	}
+	if patched != nil {
+		t.Errorf("expected the alias to stay put, got PATCH %v", patched)
+	}
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+func TestNotificationChannelValidateConfig(t *testing.T) {
+	r := valohai.NotificationChannelResource()
+	s := frameworkResource(t, r, nil).Schema
+	typ := s.Type().TerraformType(context.Background())
+
+	cases := map[string]struct {
+		values map[string]interface{}
+		valid  bool
+	}{
+		"slack":             {map[string]interface{}{"type": "slack", "slack_webhook_url": "https://hooks.slack.com/services/T/B/x"}, true},
+		"email":             {map[string]interface{}{"type": "email", "email": "ml-alerts@example.com"}, true},
+		"unknown type":      {map[string]interface{}{"type": "sms", "email": "ml-alerts@example.com"}, false},
+		"missing target":    {map[string]interface{}{"type": "webhook"}, false},
+		"mismatched target": {map[string]interface{}{"type": "email", "email": "ml-alerts@example.com", "webhook_url": "https://example.com/"}, false},
+		"invalid email":     {map[string]interface{}{"type": "email", "email": "ml-alerts"}, false},
+	}
+	for name, c := range cases {
+		c.values["owner"], c.values["name"] = "o1", "alerts"
+		var resp resource.ValidateConfigResponse
+		r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, c.values)},
+		}, &resp)
+		if resp.Diagnostics.HasError() == c.valid {
+			t.Errorf("%s: expected valid = %v, got %v", name, c.valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestNotificationChannelCreateAndRead(t *testing.T) {
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "c1"})
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "c1", "owner": map[string]interface{}{"id": 12}, "name": "renamed", "kind": "slack", "config": map[string]interface{}{}})
+		}
+	})
+	values := map[string]interface{}{"id": unknown, "owner": "12", "name": "alerts", "type": "slack", "slack_webhook_url": "https://hooks.slack.com/services/T/B/x"}
+	state, resp := frameworkCreate(t, valohai.NotificationChannelResource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if created["kind"] != "slack" || created["config"].(map[string]interface{})["url"] != "https://hooks.slack.com/services/T/B/x" {
+		t.Errorf("unexpected create payload %v", created)
+	}
+	if got := stateString(t, state, "id"); got != "c1" {
+		t.Errorf("expected id c1, got %q", got)
+	}
+
+	values["id"] = "c1"
+	state, readResp := frameworkRead(t, valohai.NotificationChannelResource(), meta, values)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", readResp.Diagnostics)
+	}
+	if got := stateString(t, state, "name"); got != "renamed" {
+		t.Errorf("expected the renamed channel, got %q", got)
+	}
+	if got := stateString(t, state, "slack_webhook_url"); got != "https://hooks.slack.com/services/T/B/x" {
+		t.Errorf("expected the write-only Slack URL to be kept, got %q", got)
+	}
+}
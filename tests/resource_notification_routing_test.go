@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+func TestNotificationRoutingValidateConfig(t *testing.T) {
+	r := valohai.NotificationRoutingResource()
+	s := frameworkResource(t, r, nil).Schema
+	typ := s.Type().TerraformType(context.Background())
+
+	cases := map[string]struct {
+		values map[string]interface{}
+		valid  bool
+	}{
+		"project":      {map[string]interface{}{"project": "p1", "events": []interface{}{"execution_failed"}}, true},
+		"organization": {map[string]interface{}{"organization": "o1", "events": []interface{}{"pipeline_completed"}}, true},
+		"no scope":     {map[string]interface{}{"events": []interface{}{"execution_failed"}}, false},
+		"both scopes":  {map[string]interface{}{"project": "p1", "organization": "o1", "events": []interface{}{"execution_failed"}}, false},
+		"no events":    {map[string]interface{}{"project": "p1", "events": []interface{}{}}, false},
+		"bad event":    {map[string]interface{}{"project": "p1", "events": []interface{}{"execution_exploded"}}, false},
+	}
+	for name, c := range cases {
+		c.values["channel"] = "c1"
+		var resp resource.ValidateConfigResponse
+		r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, c.values)},
+		}, &resp)
+		if resp.Diagnostics.HasError() == c.valid {
+			t.Errorf("%s: expected valid = %v, got %v", name, c.valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestNotificationRoutingCreateAndRead(t *testing.T) {
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "n1"})
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":           "n1",
+				"channel":      map[string]interface{}{"id": "c1"},
+				"project":      "p1",
+				"organization": nil,
+				"events":       []string{"execution_failed", "pipeline_failed"},
+			})
+		}
+	})
+	values := map[string]interface{}{"id": unknown, "channel": "c1", "project": "p1", "events": []interface{}{"execution_failed"}}
+	_, resp := frameworkCreate(t, valohai.NotificationRoutingResource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if created["project"] != "p1" || created["channel"] != "c1" {
+		t.Errorf("unexpected create payload %v", created)
+	}
+	if _, ok := created["organization"]; ok {
+		t.Errorf("expected no organization in a project routing, got %v", created)
+	}
+
+	values["id"] = "n1"
+	state, readResp := frameworkRead(t, valohai.NotificationRoutingResource(), meta, values)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", readResp.Diagnostics)
+	}
+	var events []string
+	state.GetAttribute(context.Background(), path.Root("events"), &events)
+	if len(events) != 2 {
+		t.Errorf("expected the events added outside Terraform to show as drift, got %v", events)
+	}
+	if got := stateString(t, state, "organization"); got != "" {
+		t.Errorf("expected no organization, got %q", got)
+	}
+}
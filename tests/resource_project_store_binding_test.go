@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+func TestProjectStoreBindingCreateAndRead(t *testing.T) {
+	var created map[string]interface{}
+	remote := map[string]interface{}{"store": "s1", "allow_read": true, "allow_write": false}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v0/projects/p1/stores/":
+			json.NewDecoder(r.Body).Decode(&created)
+			// The create response omits the flags: they must come from the read-back
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"store": "s1"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v0/projects/p1/stores/s1/":
+			json.NewEncoder(w).Encode(remote)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	state, resp := frameworkCreate(t, valohai.ProjectStoreBindingResource(), meta, map[string]interface{}{
+		"id":          unknown,
+		"project":     "p1",
+		"store":       "s1",
+		"allow_read":  true,
+		"allow_write": false,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if created["store"] != "s1" || created["allow_write"] != false {
+		t.Errorf("unexpected create payload %v", created)
+	}
+	if got := stateString(t, state, "id"); got != "p1/s1" {
+		t.Errorf("expected id p1/s1, got %q", got)
+	}
+	if !stateBool(t, state, "allow_read") || stateBool(t, state, "allow_write") {
+		t.Errorf("expected the flags read back from the API, got %v", state.Raw)
+	}
+
+	// allow_read was switched off in the UI
+	remote["allow_read"] = false
+	state, readResp := frameworkRead(t, valohai.ProjectStoreBindingResource(), meta, map[string]interface{}{
+		"id": "p1/s1", "project": "p1", "store": "s1", "allow_read": true, "allow_write": false,
+	})
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", readResp.Diagnostics)
+	}
+	if stateBool(t, state, "allow_read") {
+		t.Error("expected the remote allow_read change to be detected")
+	}
+}
+
+func TestProjectStoreBindingReadRemoved(t *testing.T) {
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	state, resp := frameworkRead(t, valohai.ProjectStoreBindingResource(), meta, map[string]interface{}{
+		"id": "p1/s1", "project": "p1", "store": "s1", "allow_read": true, "allow_write": true,
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !state.Raw.IsNull() {
+		t.Error("expected the binding to be removed from state")
+	}
+}
+
+func TestProjectStoreBindingImport(t *testing.T) {
+	r := valohai.ProjectStoreBindingResource().(resource.ResourceWithImportState)
+	s := frameworkResource(t, r, testMeta()).Schema
+	for id, valid := range map[string]bool{"p1/s1": true, "p1": false, "/s1": false} {
+		resp := resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: frameworkValue(t, s.Type().TerraformType(context.Background()), nil)}}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, &resp)
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("import %q: unexpected diagnostics %v", id, resp.Diagnostics)
+			continue
+		}
+		if valid && (stateString(t, resp.State, "project") != "p1" || stateString(t, resp.State, "store") != "s1") {
+			t.Errorf("import %q: unexpected state %v", id, resp.State.Raw)
+		}
+	}
+}
+
+func TestProjectStoreBindingUpdateAndDelete(t *testing.T) {
+	var requests []string
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(map[string]interface{}{"store": "s1", "allow_read": body["allow_read"], "allow_write": body["allow_write"]})
+	})
+	binding := map[string]interface{}{"id": "p1/s1", "project": "p1", "store": "s1", "allow_read": true, "allow_write": true}
+	planned := map[string]interface{}{"id": "p1/s1", "project": "p1", "store": "s1", "allow_read": true, "allow_write": false}
+
+	state, resp := frameworkUpdate(t, valohai.ProjectStoreBindingResource(), meta, binding, planned)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if stateBool(t, state, "allow_write") {
+		t.Error("expected allow_write to be switched off")
+	}
+	if del := frameworkDelete(t, valohai.ProjectStoreBindingResource(), meta, planned); del.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", del.Diagnostics)
+	}
+	want := []string{"PATCH /api/v0/projects/p1/stores/s1/", "DELETE /api/v0/projects/p1/stores/s1/"}
+	if len(requests) != 2 || requests[0] != want[0] || requests[1] != want[1] {
+		t.Errorf("expected %v, got %v", want, requests)
+	}
+}
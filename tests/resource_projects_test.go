@@ -2,6 +2,7 @@ package tests
 
 import (
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -46,6 +47,7 @@ func TestAccValohaiProjectBasic(t *testing.T) {
 resource "valohai_project" "test" {
   name  = "` + name + `"
   owner = "` + valohaiOwner + `"
+  deletion_protection = false
   description = "Project created by acceptance test"
 }
 `,
@@ -82,6 +84,7 @@ func TestAccValohaiProjectUpdate(t *testing.T) {
 resource "valohai_project" "test" {
   name  = "` + name + `"
   owner = "` + valohaiOwner + `"
+  deletion_protection = false
   description = "Initial description"
 }
 `,
@@ -92,6 +95,7 @@ resource "valohai_project" "test" {
 resource "valohai_project" "test" {
   name  = "` + name + `"
   owner = "` + valohaiOwner + `"
+  deletion_protection = false
   description = "Updated description"
 }
 `,
@@ -125,6 +129,7 @@ func TestAccValohaiProjectDelete(t *testing.T) {
 resource "valohai_project" "test" {
   name  = "` + name + `"
   owner = "` + valohaiOwner + `"
+  deletion_protection = false
   description = "Project to delete"
 }
 `,
@@ -138,3 +143,39 @@ resource "valohai_project" "test" {
 		},
 	})
 }
+
+func TestAccValohaiProjectDeletionProtection(t *testing.T) {
+	if os.Getenv("VALOHAI_API_TOKEN") == "" {
+		t.Skip("VALOHAI_API_TOKEN is not set; skipping acceptance test.")
+	}
+	valohaiOwner := getValohaiOwner()
+	name := uniqueName("tf-acc-test-project-protected")
+	config := func(protected string) string {
+		return `
+resource "valohai_project" "test" {
+  name  = "` + name + `"
+  owner = "` + valohaiOwner + `"
+  deletion_protection = ` + protected + `
+}
+`
+	}
+	defer deleteTestStateFiles()
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: ProviderFactories,
+		CheckDestroy:      testAccCheckValohaiProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("true"),
+				Check:  resource.TestCheckResourceAttr("valohai_project.test", "deletion_protection", "true"),
+			},
+			{
+				Config:      "# Empty config to trigger resource deletion\n",
+				ExpectError: regexp.MustCompile("deletion_protection is enabled"),
+			},
+			{
+				Config: config("false"),
+				Check:  resource.TestCheckResourceAttr("valohai_project.test", "deletion_protection", "false"),
+			},
+		},
+	})
+}
@@ -1,9 +1,14 @@
 package tests
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/tacy-ops/terraform-provider-valohai/valohai"
 )
 
@@ -20,3 +25,261 @@ func TestGetOptionalString(t *testing.T) {
 		t.Errorf("expected empty string, got '%s'", got)
 	}
 }
+
+func TestProjectMtimeOnlyUnknownOnUpdate(t *testing.T) {
+	r := valohai.ResourceProject()
+	state := &terraform.InstanceState{
+		ID: "0184b8f5-1111-2222-3333-444455556666",
+		Attributes: map[string]string{
+			"id":                    "0184b8f5-1111-2222-3333-444455556666",
+			"name":                  "demo",
+			"owner":                 "42",
+			"default_notifications": "true",
+			"url":                   "https://app.valohai.com/api/v0/projects/0184b8f5-1111-2222-3333-444455556666/",
+			"ctime":                 "2024-01-01T00:00:00Z",
+			"mtime":                 "2024-01-01T00:00:00Z",
+			"deletion_protection":   "true",
+			"tags.#":                "0",
+		},
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":  "demo",
+		"owner": "42",
+	}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no diff for unchanged project, got %#v", diff.Attributes)
+	}
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":  "renamed",
+		"owner": "42",
+	}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := diff.Attributes["mtime"]; a == nil || !a.NewComputed {
+		t.Fatalf("expected mtime to be unknown on update, got %#v", a)
+	}
+	if a := diff.Attributes["ctime"]; a != nil && a.NewComputed {
+		t.Fatal("expected ctime to keep its state value on update")
+	}
+}
+
+func TestProjectDeletionProtection(t *testing.T) {
+	calls := 0
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r := valohai.ResourceProject()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "demo", "owner": "42"})
+	d.SetId("0184b8f5-1111-2222-3333-444455556666")
+	diags := r.DeleteContext(context.Background(), d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "deletion_protection is enabled") {
+		t.Fatalf("expected deletion protection error, got %v", diags)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no API call for a protected project, got %d", calls)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "demo", "owner": "42", "deletion_protection": false})
+	d.SetId("0184b8f5-1111-2222-3333-444455556666")
+	if diags := r.DeleteContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if calls != 1 {
+		t.Fatalf("expected one DELETE request, got %d", calls)
+	}
+}
+
+func TestProjectTags(t *testing.T) {
+	const id = "0184b8f5-1111-2222-3333-444455556666"
+	var put map[string]interface{}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(&put)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":    id,
+			"name":  "demo",
+			"owner": map[string]interface{}{"slug": "my-org"},
+			"tags":  []interface{}{map[string]interface{}{"project": id, "name": "finance", "color": "#00ff00"}},
+			"mtime": "2024-01-01T00:00:00Z",
+		})
+	})
+	r := valohai.ResourceProject()
+	state := &terraform.InstanceState{ID: id, Attributes: map[string]string{"id": id}}
+
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if state.Attributes["tags.#"] != "1" {
+		t.Fatalf("expected one tag in state, got %v", state.Attributes)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":  "demo",
+		"owner": "my-org",
+		"tags": []interface{}{
+			map[string]interface{}{"name": "finance", "color": "#00ff00"},
+			map[string]interface{}{"name": "research", "color": "#0000ff"},
+		},
+	}), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["tags.#"] == nil || diff.Attributes["tags.#"].New != "2" {
+		t.Fatalf("expected the added tag in the diff, got %#v", diff)
+	}
+	if _, diags := r.Apply(context.Background(), state, diff, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	tags, _ := put["tags"].([]interface{})
+	if len(tags) != 2 {
+		t.Fatalf("expected both tags to be sent, got %v", put["tags"])
+	}
+}
+
+func TestProjectTagsCleared(t *testing.T) {
+	const id = "0184b8f5-1111-2222-3333-444455556666"
+	var put map[string]interface{}
+	tags := []interface{}{map[string]interface{}{"project": id, "name": "finance", "color": "#00ff00"}}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(&put)
+			tags = []interface{}{}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":    id,
+			"name":  "demo",
+			"owner": map[string]interface{}{"slug": "my-org"},
+			"tags":  tags,
+			"mtime": "2024-01-01T00:00:00Z",
+		})
+	})
+	r := valohai.ResourceProject()
+	state := &terraform.InstanceState{ID: id, Attributes: map[string]string{"id": id, "deletion_protection": "true"}}
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":  "demo",
+		"owner": "my-org",
+	}), meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["tags.#"] == nil || diff.Attributes["tags.#"].New != "0" {
+		t.Fatalf("expected removing the tags block to plan removing the tags, got %#v", diff)
+	}
+	if _, diags := r.Apply(context.Background(), state, diff, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	tags, ok := put["tags"].([]interface{})
+	if !ok || len(tags) != 0 {
+		t.Fatalf("expected an explicit empty tags list, got %v", put)
+	}
+}
+
+func TestProjectDefaultEnvironmentValidation(t *testing.T) {
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/environments/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": []interface{}{
+			map[string]interface{}{"slug": "aws-eu-west-1-t3-medium"},
+			map[string]interface{}{"slug": "aws-eu-west-1-p3-2xlarge"},
+		}})
+	})
+	r := valohai.ResourceProject()
+	plan := func(env string) error {
+		_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":                "demo",
+			"owner":               "my-org",
+			"default_environment": env,
+		}), meta)
+		return err
+	}
+
+	if err := plan("aws-eu-west-1-p3-2xlarge"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := plan("aws-eu-west-1-g5-xlarge")
+	if err == nil || !strings.Contains(err.Error(), `default_environment "aws-eu-west-1-g5-xlarge"`) {
+		t.Fatalf("expected an unknown environment error, got %v", err)
+	}
+}
+
+func TestProjectDefaultEnvironmentValidationSkipped(t *testing.T) {
+	const id = "0184b8f5-1111-2222-3333-444455556666"
+	listed := 0
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		listed++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	r := valohai.ResourceProject()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                "demo",
+		"owner":               "my-org",
+		"default_environment": "aws-eu-west-1-t3-medium",
+	})
+
+	// An unchanged default_environment is not looked up again
+	state := &terraform.InstanceState{ID: id, Attributes: map[string]string{
+		"id":                  id,
+		"name":                "demo",
+		"owner":               "my-org",
+		"default_environment": "aws-eu-west-1-t3-medium",
+	}}
+	if _, err := r.Diff(context.Background(), state, config, meta); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listed != 0 {
+		t.Fatalf("expected no environment listing for an unchanged default_environment, got %d requests", listed)
+	}
+
+	// A failing listing does not block the plan
+	if _, err := r.Diff(context.Background(), nil, config, meta); err != nil {
+		t.Fatalf("expected the check to be skipped when listing fails, got %v", err)
+	}
+	if listed == 0 {
+		t.Fatal("expected the environments to be listed for a new default_environment")
+	}
+}
+
+func TestProjectExecutionDefaultsRead(t *testing.T) {
+	const id = "0184b8f5-1111-2222-3333-444455556666"
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":                           id,
+			"name":                         "demo",
+			"default_environment":          "aws-eu-west-1-t3-medium",
+			"default_image":                "python:3.12",
+			"execution_time_limit_seconds": 3600,
+			"no_debug":                     true,
+		})
+	})
+	r := valohai.ResourceProject()
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), &terraform.InstanceState{ID: id, Attributes: map[string]string{"id": id}}, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	for k, want := range map[string]string{
+		"default_environment":          "aws-eu-west-1-t3-medium",
+		"default_image":                "python:3.12",
+		"execution_time_limit_seconds": "3600",
+		"no_debug":                     "true",
+	} {
+		if got := state.Attributes[k]; got != want {
+			t.Errorf("expected %s = %q, got %q", k, want, got)
+		}
+	}
+}
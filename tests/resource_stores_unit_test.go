@@ -0,0 +1,185 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+const softDeletedStoreID = "0184b8f5-aaaa-bbbb-cccc-ddddeeeeffff"
+
+// softDeletedStoreHandler serves a store flagged as deleted until a PATCH
+// clears the flag. Like the API, every write moves mtime forward.
+func softDeletedStoreHandler(t *testing.T, patches *[]map[string]interface{}) http.HandlerFunc {
+	deleted := true
+	name := "demo"
+	mtime := "2024-01-01T00:00:00Z"
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/stores/"+softDeletedStoreID+"/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPatch:
+			var body map[string]interface{}
+			b, _ := io.ReadAll(r.Body)
+			json.Unmarshal(b, &body)
+			*patches = append(*patches, body)
+			deleted = false
+			mtime = "2024-01-02T00:00:00Z"
+		case http.MethodPut:
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			name, _ = body["name"].(string)
+			mtime = "2024-01-03T00:00:00Z"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":      softDeletedStoreID,
+			"name":    name,
+			"type":    "s3",
+			"owner":   42,
+			"deleted": deleted,
+			"mtime":   mtime,
+		})
+	}
+}
+
+func readSoftDeletedStore(t *testing.T, onSoftDeleted string, patches *[]map[string]interface{}) (*schema.ResourceData, diag.Diagnostics) {
+	t.Helper()
+	r := valohai.ResourceStore()
+	raw := map[string]interface{}{"name": "demo", "type": "s3"}
+	if onSoftDeleted != "" {
+		raw["on_soft_deleted"] = onSoftDeleted
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.SetId(softDeletedStoreID)
+	return d, r.ReadContext(context.Background(), d, mockAPIMeta(t, softDeletedStoreHandler(t, patches)))
+}
+
+func TestStoreReadSoftDeletedRecreate(t *testing.T) {
+	var patches []map[string]interface{}
+	d, diags := readSoftDeletedStore(t, "", &patches)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected soft-deleted store to be removed from state, got id %q", d.Id())
+	}
+	if len(patches) != 0 {
+		t.Errorf("expected no restore request, got %v", patches)
+	}
+}
+
+func TestStoreReadSoftDeletedRestore(t *testing.T) {
+	var patches []map[string]interface{}
+	d, diags := readSoftDeletedStore(t, "restore", &patches)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != softDeletedStoreID {
+		t.Errorf("expected store to stay in state, got id %q", d.Id())
+	}
+	if !d.Get("soft_deleted").(bool) {
+		t.Error("expected soft_deleted to be recorded in state")
+	}
+	// Refresh, and so terraform plan, must not change the store
+	if len(patches) != 0 {
+		t.Errorf("expected no PATCH during refresh, got %v", patches)
+	}
+}
+
+func TestStoreRestoreOnApply(t *testing.T) {
+	var patches []map[string]interface{}
+	meta := mockAPIMeta(t, softDeletedStoreHandler(t, &patches))
+	r := valohai.ResourceStore()
+	state := &terraform.InstanceState{
+		ID: softDeletedStoreID,
+		Attributes: map[string]string{
+			"id":                  softDeletedStoreID,
+			"name":                "demo",
+			"type":                "s3",
+			"on_soft_deleted":     "restore",
+			"deletion_protection": "false",
+			"soft_deleted":        "true",
+			"mtime":               "2024-01-01T00:00:00Z",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "demo", "type": "s3", "on_soft_deleted": "restore"})
+
+	diff, err := r.Diff(context.Background(), state, config, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["soft_deleted"] == nil || diff.Attributes["soft_deleted"].New != "false" {
+		t.Fatalf("expected the plan to restore the store, got %v", diff)
+	}
+	if diff.Attributes["mtime"] == nil || !diff.Attributes["mtime"].NewComputed {
+		t.Errorf("expected the restore to plan mtime as unknown, got %v", diff.Attributes["mtime"])
+	}
+	if len(patches) != 0 {
+		t.Fatalf("expected no PATCH while planning, got %v", patches)
+	}
+
+	newState, diags := r.Apply(context.Background(), state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(patches) != 1 || patches[0]["deleted"] != false {
+		t.Errorf("expected one PATCH with deleted=false on apply, got %v", patches)
+	}
+	if newState.Attributes["soft_deleted"] != "false" {
+		t.Errorf("expected soft_deleted = false after apply, got %q", newState.Attributes["soft_deleted"])
+	}
+}
+
+func TestStoreRestoreWithUpdate(t *testing.T) {
+	var patches []map[string]interface{}
+	meta := mockAPIMeta(t, softDeletedStoreHandler(t, &patches))
+	r := valohai.ResourceStore()
+	state := &terraform.InstanceState{
+		ID: softDeletedStoreID,
+		Attributes: map[string]string{
+			"id":                  softDeletedStoreID,
+			"name":                "demo",
+			"type":                "s3",
+			"on_soft_deleted":     "restore",
+			"deletion_protection": "false",
+			"soft_deleted":        "true",
+			"mtime":               "2024-01-01T00:00:00Z",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "renamed", "type": "s3", "on_soft_deleted": "restore"})
+
+	diff, err := r.Diff(context.Background(), state, config, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The restore moves mtime, which must not be mistaken for a concurrent change
+	newState, diags := r.Apply(context.Background(), state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(patches) != 1 {
+		t.Errorf("expected one restore PATCH, got %v", patches)
+	}
+	if newState.Attributes["name"] != "renamed" || newState.Attributes["soft_deleted"] != "false" {
+		t.Errorf("expected the store to be restored and renamed, got %v", newState.Attributes)
+	}
+}
+
+func TestStoreReadSoftDeletedError(t *testing.T) {
+	var patches []map[string]interface{}
+	_, diags := readSoftDeletedStore(t, "error", &patches)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "deleted outside Terraform") {
+		t.Fatalf("expected soft-delete error, got %v", diags)
+	}
+}
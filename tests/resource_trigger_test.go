@@ -0,0 +1,216 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+const triggerID = "0184b8f5-ffff-2222-3333-444455556666"
+
+func webhookTriggerValues() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          unknown,
+		"project":     "p1",
+		"title":       "Retrain on new data",
+		"enabled":     true,
+		"type":        unknown,
+		"webhook_url": unknown,
+		"webhook": []interface{}{
+			map[string]interface{}{"secret": "s3cret"},
+		},
+		"copy_pipeline": []interface{}{
+			map[string]interface{}{"source_pipeline": "pl1", "payload_input_name": "payload"},
+		},
+	}
+}
+
+func TestTriggerCreate(t *testing.T) {
+	var created map[string]interface{}
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v0/triggers/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&created)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": triggerID, "webhook_url": "https://app.valohai.com/api/v0/launch/" + triggerID + "/"})
+	})
+	values := webhookTriggerValues()
+	values["type"] = "webhook"
+	state, resp := frameworkCreate(t, valohai.TriggerResource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if created["trigger_type"] != "webhook" {
+		t.Errorf("expected a webhook trigger, got %v", created["trigger_type"])
+	}
+	conditions := created["conditions"].([]interface{})
+	condition := conditions[0].(map[string]interface{})
+	if condition["type"] != "web-request-auth" || condition["auth_type"] != "static-secret" || condition["secret"] != "s3cret" {
+		t.Errorf("unexpected conditions %v", conditions)
+	}
+	action := created["actions"].([]interface{})[0].(map[string]interface{})
+	if action["type"] != "copy-pipeline" || action["source_pipeline"] != "pl1" || action["payload_input_name"] != "payload" {
+		t.Errorf("unexpected action %v", action)
+	}
+	if _, ok := action["title"]; ok {
+		t.Errorf("expected unset title to be omitted, got %v", action)
+	}
+	if got := stateString(t, state, "webhook_url"); got == "" {
+		t.Error("expected webhook_url to be set")
+	}
+}
+
+func TestTriggerReadKeepsSecret(t *testing.T) {
+	meta := mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":          triggerID,
+			"project":     map[string]interface{}{"id": "p1"},
+			"title":       "Retrain nightly",
+			"enabled":     false,
+			"webhook_url": "",
+			"conditions":  []interface{}{map[string]interface{}{"type": "cron", "schedule": "0 3 * * *"}},
+			"actions":     []interface{}{map[string]interface{}{"type": "run-execution", "source_execution": map[string]interface{}{"id": "e1"}, "title": ""}},
+		})
+	})
+	state, resp := frameworkRead(t, valohai.TriggerResource(), meta, map[string]interface{}{"id": triggerID})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if got := stateString(t, state, "type"); got != "scheduled" {
+		t.Errorf("expected a scheduled trigger, got %q", got)
+	}
+	if stateBool(t, state, "enabled") {
+		t.Error("expected enabled = false from the API")
+	}
+	var cron, execution, title *string
+	state.GetAttribute(context.Background(), path.Root("schedule").AtListIndex(0).AtName("cron"), &cron)
+	state.GetAttribute(context.Background(), path.Root("run_execution").AtListIndex(0).AtName("source_execution"), &execution)
+	state.GetAttribute(context.Background(), path.Root("run_execution").AtListIndex(0).AtName("title"), &title)
+	if cron == nil || *cron != "0 3 * * *" || execution == nil || *execution != "e1" || title != nil {
+		t.Errorf("unexpected blocks: cron %v, execution %v, title %v", cron, execution, title)
+	}
+
+	// Webhook secrets are write-only: the secret from state survives a refresh
+	meta = mockAPIMeta(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":         triggerID,
+			"project":    "p1",
+			"conditions": []interface{}{map[string]interface{}{"type": "web-request-auth", "auth_type": "static-secret"}},
+		})
+	})
+	values := webhookTriggerValues()
+	values["id"] = triggerID
+	state, resp = frameworkRead(t, valohai.TriggerResource(), meta, values)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	var secret, authType *string
+	state.GetAttribute(context.Background(), path.Root("webhook").AtListIndex(0).AtName("secret"), &secret)
+	state.GetAttribute(context.Background(), path.Root("webhook").AtListIndex(0).AtName("auth_type"), &authType)
+	if secret == nil || *secret != "s3cret" {
+		t.Errorf("expected the secret to be kept, got %v", secret)
+	}
+	if authType != nil {
+		t.Errorf("expected the default auth_type to stay unset, got %q", *authType)
+	}
+}
+
+func TestTriggerValidateConfig(t *testing.T) {
+	r := valohai.TriggerResource()
+	s := frameworkResource(t, r, nil).Schema
+	typ := s.Type().TerraformType(context.Background())
+
+	cases := map[string]map[string]interface{}{
+		"no condition": {"project": "p1", "title": "t", "copy_pipeline": []interface{}{map[string]interface{}{"source_pipeline": "pl1"}}},
+		"no action":    {"project": "p1", "title": "t", "schedule": []interface{}{map[string]interface{}{"cron": "0 3 * * *"}}},
+		"bad cron": {"project": "p1", "title": "t",
+			"schedule":      []interface{}{map[string]interface{}{"cron": "@daily"}},
+			"copy_pipeline": []interface{}{map[string]interface{}{"source_pipeline": "pl1"}}},
+		"bad auth_type": {"project": "p1", "title": "t",
+			"webhook":       []interface{}{map[string]interface{}{"secret": "x", "auth_type": "basic"}},
+			"copy_pipeline": []interface{}{map[string]interface{}{"source_pipeline": "pl1"}}},
+	}
+	for name, values := range cases {
+		var resp resource.ValidateConfigResponse
+		r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, values)},
+		}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
+
+func TestTriggerTypeChangeRequiresReplace(t *testing.T) {
+	r := valohai.TriggerResource()
+	s := frameworkResource(t, r, nil).Schema
+	typ := s.Type().TerraformType(context.Background())
+
+	prior := webhookTriggerValues()
+	prior["id"], prior["type"], prior["webhook_url"] = triggerID, "webhook", "https://example.invalid/"
+	planned := webhookTriggerValues()
+	planned["id"], planned["type"], planned["webhook_url"] = triggerID, unknown, "https://example.invalid/"
+	delete(planned, "webhook")
+	planned["schedule"] = []interface{}{map[string]interface{}{"cron": "0 3 * * *"}}
+
+	plan := tfsdk.Plan{Schema: s, Raw: frameworkValue(t, typ, planned)}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, planned)},
+		Plan:   plan,
+		State:  tfsdk.State{Schema: s, Raw: frameworkValue(t, typ, prior)},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if len(resp.RequiresReplace) != 1 || !resp.RequiresReplace[0].Equal(path.Root("type")) {
+		t.Errorf("expected type to require replacement, got %v", resp.RequiresReplace)
+	}
+	var planType string
+	resp.Plan.GetAttribute(context.Background(), path.Root("type"), &planType)
+	if planType != "scheduled" {
+		t.Errorf("expected planned type scheduled, got %q", planType)
+	}
+}
+
+func TestTriggerUnknownBlocks(t *testing.T) {
+	r := valohai.TriggerResource()
+	s := frameworkResource(t, r, nil).Schema
+	typ := s.Type().TerraformType(context.Background())
+
+	// e.g. dynamic "webhook" over a value only known after apply
+	values := webhookTriggerValues()
+	values["webhook"] = unknown
+	config := tfsdk.Config{Schema: s, Raw: frameworkValue(t, typ, values)}
+
+	var validateResp resource.ValidateConfigResponse
+	r.(resource.ResourceWithValidateConfig).ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, &validateResp)
+	if validateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected validation error: %v", validateResp.Diagnostics)
+	}
+
+	plan := tfsdk.Plan{Schema: s, Raw: frameworkValue(t, typ, values)}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Config: config,
+		Plan:   plan,
+		State:  tfsdk.State{Schema: s, Raw: frameworkValue(t, typ, nil)},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected plan error: %v", resp.Diagnostics)
+	}
+	var planType types.String
+	resp.Plan.GetAttribute(context.Background(), path.Root("type"), &planType)
+	if !planType.IsUnknown() {
+		t.Errorf("expected type to stay unknown, got %v", planType)
+	}
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+// upgradeState runs a recorded raw state through the resource upgrade chain,
+// then checks that the result decodes with the current schema.
+func upgradeState(t *testing.T, r *schema.Resource, version int, raw string) map[string]interface{} {
+	t.Helper()
+	var state map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		t.Fatalf("invalid recorded state: %v", err)
+	}
+	for _, u := range r.StateUpgraders {
+		if u.Version < version {
+			continue
+		}
+		var err error
+		state, err = u.Upgrade(context.Background(), state, nil)
+		if err != nil {
+			t.Fatalf("upgrade from version %d failed: %v", u.Version, err)
+		}
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctyjson.Unmarshal(b, r.CoreConfigSchema().ImpliedType()); err != nil {
+		t.Fatalf("upgraded state does not match schema version %d: %v", r.SchemaVersion, err)
+	}
+	return state
+}
+
+func TestProjectStateUpgradeV0(t *testing.T) {
+	r := valohai.ResourceProject()
+	state := upgradeState(t, r, 0, `{
+		"id": "017f1d3c-8e0a-4b3e-9d4e-3f1a2b3c4d5e",
+		"name": "my-project",
+		"owner": "my-org",
+		"description": "Managed by Terraform",
+		"template_url": "",
+		"default_notifications": "true"
+	}`)
+	if state["default_notifications"] != true {
+		t.Fatalf("expected default_notifications to be true, got %v", state["default_notifications"])
+	}
+
+	state = upgradeState(t, r, 0, `{"id": "x", "name": "p", "owner": "o", "default_notifications": null}`)
+	if state["default_notifications"] != nil {
+		t.Fatalf("expected default_notifications to stay null, got %v", state["default_notifications"])
+	}
+}
+
+func TestProjectStateUpgradeV1(t *testing.T) {
+	r := valohai.ResourceProject()
+	state := upgradeState(t, r, 1, `{"id": "x", "name": "p", "owner": "o", "default_notifications": true}`)
+	if state["deletion_protection"] != true {
+		t.Fatalf("expected deletion_protection to be enabled, got %v", state["deletion_protection"])
+	}
+
+	state = upgradeState(t, r, 1, `{"id": "x", "name": "p", "owner": "o", "deletion_protection": false}`)
+	if state["deletion_protection"] != false {
+		t.Fatalf("expected deletion_protection to stay false, got %v", state["deletion_protection"])
+	}
+}
+
+func TestStoreStateUpgradeV0(t *testing.T) {
+	r := valohai.ResourceStore()
+	// State written by the provider before deletion_protection existed
+	state := upgradeState(t, r, 0, `{
+		"id": "017f1d3c-8e0a-4b3e-9d4e-3f1a2b3c4d5e",
+		"name": "my-store",
+		"type": "s3",
+		"access_mode": "owner_organization",
+		"allow_read": true,
+		"allow_write": true,
+		"allow_uri_download": false,
+		"configuration": {"bucket": "example-bucket", "region": "eu-west-1"},
+		"owner_id": 9506,
+		"project": "",
+		"paths": {},
+		"teams": null,
+		"url": "https://app.valohai.com/api/v0/stores/017f1d3c-8e0a-4b3e-9d4e-3f1a2b3c4d5e/"
+	}`)
+	if state["deletion_protection"] != false {
+		t.Fatalf("expected deletion_protection to default to false, got %v", state["deletion_protection"])
+	}
+
+	state = upgradeState(t, r, 0, `{"id": "x", "name": "s", "type": "s3", "deletion_protection": true}`)
+	if state["deletion_protection"] != true {
+		t.Fatalf("expected deletion_protection to stay true, got %v", state["deletion_protection"])
+	}
+}
+
+// Team and registry credentials state needs no upgrader: state recorded with
+// the first provider version must decode with the current schema unchanged.
+func TestTeamStateWithoutUpgrade(t *testing.T) {
+	r := valohai.ResourceTeam()
+	if r.SchemaVersion != 0 || len(r.StateUpgraders) != 0 {
+		t.Fatalf("expected no schema version, got %d", r.SchemaVersion)
+	}
+	state := upgradeState(t, r, 0, `{
+		"id": "017f1d3c-8e0a-4b3e-9d4e-3f1a2b3c4d5e",
+		"name": "my-team",
+		"organization": 9506,
+		"url": "https://app.valohai.com/api/v0/teams/017f1d3c-8e0a-4b3e-9d4e-3f1a2b3c4d5e/"
+	}`)
+	if state["organization"] != float64(9506) {
+		t.Fatalf("expected organization 9506, got %v", state["organization"])
+	}
+}
+
+func TestRegistryCredentialsStateWithoutUpgrade(t *testing.T) {
+	r := valohai.ResourceRegistryCredentials()
+	if r.SchemaVersion != 0 || len(r.StateUpgraders) != 0 {
+		t.Fatalf("expected no schema version, got %d", r.SchemaVersion)
+	}
+	upgradeState(t, r, 0, `{
+		"id": "017f1d3c-8e0a-4b3e-9d4e-3f1a2b3c4d5e",
+		"type": "docker",
+		"image_pattern": "docker.io/my-org/*",
+		"owner": 9506,
+		"configuration": {"username": "robot", "password": "secret"}
+	}`)
+}
@@ -1,10 +1,16 @@
 package tests
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"testing"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tacy-ops/terraform-provider-valohai/valohai"
 )
@@ -13,6 +19,17 @@ var ProviderFactories = map[string]func() (*schema.Provider, error){
 	"valohai": func() (*schema.Provider, error) { return valohai.Provider(), nil },
 }
 
+// ProtoV5ProviderFactories serves the muxed provider, required for framework resources.
+var ProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"valohai": func() (tfprotov5.ProviderServer, error) {
+		server, err := valohai.ProviderServer(context.Background(), "test")
+		if err != nil {
+			return nil, err
+		}
+		return server(), nil
+	},
+}
+
 func uniqueName(base string) string {
 	return fmt.Sprintf("%s-%s", base, uuid.New().String())
 }
@@ -33,3 +50,25 @@ func getValohaiOrganization() string {
 	}
 	return organization
 }
+
+// mockAPIMeta returns provider meta whose HTTP client sends every request,
+// whatever its host, to handler instead of the Valohai API.
+func mockAPIMeta(t *testing.T, handler http.HandlerFunc) map[string]interface{} {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL)
+	client := &http.Client{Transport: rewriteHostTransport{target: target}}
+	return map[string]interface{}{"token": "test-token", "http_client": client}
+}
+
+type rewriteHostTransport struct {
+	target *url.URL
+}
+
+func (t rewriteHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tacy-ops/terraform-provider-valohai/valohai"
+)
+
+// laggingTeamAPI creates a team that only becomes readable after notFound GETs,
+// then answers readStatus.
+func laggingTeamAPI(notFound, readStatus int, gets *int) http.HandlerFunc {
+	team := map[string]interface{}{
+		"id":           "0184b8f5-9999-2222-3333-444455556666",
+		"name":         "ml",
+		"organization": 42,
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(team)
+			return
+		}
+		*gets++
+		if *gets <= notFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(readStatus)
+		json.NewEncoder(w).Encode(team)
+	}
+}
+
+func TestCreateWaitsUntilReadable(t *testing.T) {
+	gets := 0
+	r := valohai.ResourceTeam()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "ml", "organization": 42})
+	if diags := r.CreateContext(context.Background(), d, mockAPIMeta(t, laggingTeamAPI(1, http.StatusOK, &gets))); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if gets != 2 {
+		t.Errorf("expected the create to poll until readable, got %d GET requests", gets)
+	}
+	if d.Id() == "" {
+		t.Error("expected the team to be kept in state")
+	}
+}
+
+func TestCreateStopsPollingOnError(t *testing.T) {
+	gets := 0
+	r := valohai.ResourceTeam()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "ml", "organization": 42})
+	diags := r.CreateContext(context.Background(), d, mockAPIMeta(t, laggingTeamAPI(0, http.StatusForbidden, &gets)))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "not readable yet") {
+		t.Fatalf("expected a readability error, got %v", diags)
+	}
+	if gets != 1 {
+		t.Errorf("expected polling to stop on a non-404 error, got %d GET requests", gets)
+	}
+}
@@ -0,0 +1,192 @@
+package valohai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// isCreateConflict reports whether a create request failed because an object
+// with the same name already exists. The API answers 409 or a 400 carrying a
+// uniqueness error on name or non_field_errors; other 400s are validation
+// errors and are not conflicts. The response body stays readable.
+func isCreateConflict(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusConflict:
+		return true
+	case http.StatusBadRequest:
+	default:
+		return false
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+
+	var errResp map[string]interface{}
+	if err := json.Unmarshal(b, &errResp); err != nil {
+		return false
+	}
+	for _, key := range []string{"name", "non_field_errors"} {
+		errs, _ := errResp[key].([]interface{})
+		for _, e := range errs {
+			if isDuplicateNameError(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDuplicateNameError matches a single field error, given either as a plain
+// message or as a {"message": ..., "code": ...} object.
+func isDuplicateNameError(e interface{}) bool {
+	switch v := e.(type) {
+	case string:
+		return strings.Contains(strings.ToLower(v), "already exists")
+	case map[string]interface{}:
+		if code, _ := v["code"].(string); code == "unique" {
+			return true
+		}
+		msg, _ := v["message"].(string)
+		return isDuplicateNameError(msg)
+	}
+	return false
+}
+
+// adoptExistingProject takes over an existing project with the configured name
+// and owner when adopt_existing is set and the create request conflicted.
+// The project is read into state as it is, so the next plan shows where it
+// differs from the configuration. It returns false when nothing was adopted.
+func adoptExistingProject(ctx context.Context, d *schema.ResourceData, m interface{}, resp *http.Response) (bool, error) {
+	if !d.Get("adopt_existing").(bool) || !isCreateConflict(resp) {
+		return false, nil
+	}
+	name := d.Get("name").(string)
+	owner := d.Get("owner").(string)
+
+	id, err := findProject(ctx, m, name, owner)
+	if err != nil || id == "" {
+		return false, err
+	}
+	tflog.Info(ctx, "Adopting existing project", map[string]interface{}{"id": id, "name": name, "owner": owner})
+	d.SetId(id)
+	return true, resourceProjectRead(ctx, d, m)
+}
+
+// findProject returns the id of the project named name whose owner slug is
+// owner, or "" when there is none. The owner is compared the way
+// resourceProjectRead stores it.
+func findProject(ctx context.Context, m interface{}, name, owner string) (string, error) {
+	var found string
+	err := ListEach(ctx, m, "https://app.valohai.com/api/v0/projects/", ListOptions{
+		Filters: map[string]string{"name": name},
+	}, func(item json.RawMessage) (bool, error) {
+		var p struct {
+			ID    string      `json:"id"`
+			Name  string      `json:"name"`
+			Owner interface{} `json:"owner"`
+		}
+		if err := json.Unmarshal(item, &p); err != nil {
+			return false, fmt.Errorf("failed to decode project: %w", err)
+		}
+		// owner is either the slug or an {"id": ..., "slug": ...} object
+		var slug string
+		switch o := p.Owner.(type) {
+		case string:
+			slug = o
+		case map[string]interface{}:
+			slug, _ = o["slug"].(string)
+		}
+		if p.Name == name && slug == owner {
+			found = p.ID
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up existing project %q: %w", name, err)
+	}
+	return found, nil
+}
+
+// adoptExistingTeam takes over an existing team with the configured name and
+// organization when adopt_existing is set and the create request conflicted.
+// It returns false when nothing was adopted.
+func adoptExistingTeam(ctx context.Context, d *schema.ResourceData, m interface{}, resp *http.Response) (bool, error) {
+	if !d.Get("adopt_existing").(bool) || !isCreateConflict(resp) {
+		return false, nil
+	}
+	name := d.Get("name").(string)
+	org := d.Get("organization").(int)
+
+	id, err := findTeam(ctx, m, name, org)
+	if err != nil || id == "" {
+		return false, err
+	}
+	tflog.Info(ctx, "Adopting existing team", map[string]interface{}{"id": id, "name": name, "organization": org})
+	d.SetId(id)
+	return true, resourceTeamRead(ctx, d, m)
+}
+
+// findTeam returns the id of the team named name in organization org, or ""
+// when there is none.
+func findTeam(ctx context.Context, m interface{}, name string, org int) (string, error) {
+	var found string
+	err := ListEach(ctx, m, "https://app.valohai.com/api/v0/teams/", ListOptions{
+		Filters: map[string]string{"name": name, "organization": strconv.Itoa(org)},
+	}, func(item json.RawMessage) (bool, error) {
+		var t struct {
+			ID           string      `json:"id"`
+			Name         string      `json:"name"`
+			Organization interface{} `json:"organization"`
+		}
+		if err := json.Unmarshal(item, &t); err != nil {
+			return false, fmt.Errorf("failed to decode team: %w", err)
+		}
+		// organization is either an id or an {"id": ..., "username": ...} object
+		teamOrg, err := decodeOwnerID(t.Organization)
+		if err != nil {
+			return false, fmt.Errorf("failed to decode team organization: %w", err)
+		}
+		if t.Name == name && fmt.Sprint(teamOrg) == strconv.Itoa(org) {
+			found = t.ID
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up existing team %q: %w", name, err)
+	}
+	return found, nil
+}
+
+// decodeOwnerID accepts an owner given as a JSON number, a numeric string or
+// an object form such as {"id": 42, "username": "org"}.
+func decodeOwnerID(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case float64, int:
+		return t, nil
+	case string:
+		if strings.TrimSpace(t) == "" {
+			return nil, nil
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(t))
+		if err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", t)
+		}
+		return n, nil
+	case map[string]interface{}:
+		return decodeOwnerID(t["id"])
+	}
+	return nil, fmt.Errorf("unexpected value %v (%T)", v, v)
+}
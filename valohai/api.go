@@ -0,0 +1,108 @@
+package valohai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// errNotFound is wrapped by doJSON when the API answers 404.
+var errNotFound = errors.New("not found")
+
+// doJSON sends payload, when not nil, as a JSON body to apiURL and decodes a
+// successful response into out, when not nil. A 404 answer wraps errNotFound;
+// other error statuses are reported with parseAPIError.
+func doJSON(ctx context.Context, m map[string]interface{}, method, apiURL string, payload, out interface{}) error {
+	authToken := m["token"].(string)
+
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode payload: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, body)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+	req.Header.Set("Authorization", "Token "+authToken)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClientFromMeta(m).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute %s request: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s %s: %w", method, apiURL, errNotFound)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return parseAPIError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// apiID returns the id of a related object, which the API sends either as a
+// bare id or as an object with an "id" key.
+func apiID(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return fmt.Sprintf("%.0f", t)
+	case map[string]interface{}:
+		return apiID(t["id"])
+	}
+	return ""
+}
+
+// setOptionalString sets key in a request body unless v is null or unknown.
+func setOptionalString(m map[string]interface{}, key string, v types.String) {
+	if !v.IsNull() && !v.IsUnknown() {
+		m[key] = v.ValueString()
+	}
+}
+
+// optionalString maps the empty strings the API returns for unset fields to null.
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// blocksKnown reports whether the named list blocks of a config or plan are
+// known, get being its GetAttribute method. A block generated with dynamic
+// over a value only known after apply is unknown as a whole, and decoding it
+// into a slice of models fails.
+func blocksKnown(ctx context.Context, get func(context.Context, path.Path, interface{}) diag.Diagnostics, names ...string) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	for _, name := range names {
+		var block types.List
+		diags.Append(get(ctx, path.Root(name), &block)...)
+		if diags.HasError() || block.IsUnknown() {
+			return false, diags
+		}
+	}
+	return true, diags
+}
@@ -0,0 +1,68 @@
+package valohai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// checkNotModifiedSinceRead guards updates against overwriting changes made in
+// the Valohai UI: it fetches the object at apiURL and fails when its mtime no
+// longer matches the one recorded in state. fields maps Terraform attributes to
+// their API keys and is used to report what changed remotely. The returned
+// ETag, if the API sent one, should be passed as If-Match on the update.
+func checkNotModifiedSinceRead(ctx context.Context, d *schema.ResourceData, m interface{}, apiURL, kind string, fields map[string]string) (string, error) {
+	stateMtime, _ := d.GetChange("mtime")
+	// Nothing to compare against for state written before mtime was tracked
+	if stateMtime.(string) == "" {
+		return "", nil
+	}
+
+	authToken := m.(map[string]interface{})["token"].(string)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GET request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+authToken)
+	resp, err := httpClientFromMeta(m).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute GET request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", parseAPIError(resp)
+	}
+
+	var remote map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&remote); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	remoteMtime, _ := remote["mtime"].(string)
+	if remoteMtime == "" || remoteMtime == stateMtime.(string) {
+		return resp.Header.Get("ETag"), nil
+	}
+
+	var changed []string
+	for attr, key := range fields {
+		old, _ := d.GetChange(attr)
+		if v, ok := remote[key]; ok && fmt.Sprint(v) != fmt.Sprint(old) {
+			changed = append(changed, fmt.Sprintf("  %s: %q in state, %q in Valohai", attr, fmt.Sprint(old), fmt.Sprint(v)))
+		}
+	}
+	sort.Strings(changed)
+	detail := "no attribute managed by Terraform differs"
+	if len(changed) > 0 {
+		detail = "changed attributes:\n" + strings.Join(changed, "\n")
+	}
+	return "", fmt.Errorf("%s %s was modified in Valohai since it was last read (mtime %s, now %s); %s\nRun terraform plan again to review the remote changes before applying", kind, d.Id(), stateMtime, remoteMtime, detail)
+}
+
+// errPreconditionFailed is returned when the API rejects an If-Match update.
+func errPreconditionFailed(kind, id string) error {
+	return fmt.Errorf("%s %s was modified in Valohai during the update; run terraform plan again to review the remote changes before applying", kind, id)
+}
@@ -0,0 +1,53 @@
+package valohai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// localOnlyAttributes only change provider behaviour and are never sent to the API.
+var localOnlyAttributes = []string{"adopt_existing", "deletion_protection", "on_soft_deleted"}
+
+// computedOnUpdate marks the given computed attributes as unknown only when an
+// update is planned, so unchanged resources keep a clean plan while values the
+// API rewrites on every write (e.g. mtime) are not reported as inconsistent.
+func computedOnUpdate(keys ...string) schema.CustomizeDiffFunc {
+	skip := make(map[string]bool, len(keys)+len(localOnlyAttributes))
+	for _, k := range keys {
+		skip[k] = true
+	}
+	for _, k := range localOnlyAttributes {
+		skip[k] = true
+	}
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		changed := false
+		for _, k := range d.GetChangedKeysPrefix("") {
+			if !skip[k] {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			return nil
+		}
+		for _, k := range keys {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// deletionProtected returns an error when deletion_protection is enabled on d.
+func deletionProtected(d *schema.ResourceData, kind string) error {
+	if !d.Get("deletion_protection").(bool) {
+		return nil
+	}
+	return fmt.Errorf("cannot delete %s %q (%s): deletion_protection is enabled; set deletion_protection = false and apply before destroying it", kind, d.Get("name"), d.Id())
+}
@@ -0,0 +1,141 @@
+package valohai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type datasetVersionDataSource struct {
+	frameworkMeta
+}
+
+var _ datasource.DataSourceWithConfigure = &datasetVersionDataSource{}
+
+func newDatasetVersionDataSource() datasource.DataSource {
+	return &datasetVersionDataSource{}
+}
+
+// DatasetVersionDataSource returns the valohai_dataset_version data source.
+func DatasetVersionDataSource() datasource.DataSource {
+	return newDatasetVersionDataSource()
+}
+
+// latestDatasetVersion is the name resolving to the most recent version.
+const latestDatasetVersion = "latest"
+
+// datasetVersionDataModel maps the valohai_dataset_version data source schema.
+type datasetVersionDataModel struct {
+	ID      types.String `tfsdk:"id"`
+	Dataset types.String `tfsdk:"dataset"`
+	Name    types.String `tfsdk:"name"`
+	Version types.String `tfsdk:"version"`
+	Datums  types.List   `tfsdk:"datums"`
+	URI     types.String `tfsdk:"uri"`
+	Ctime   types.String `tfsdk:"ctime"`
+}
+
+func (d *datasetVersionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset_version"
+}
+
+func (d *datasetVersionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a version of a Valohai dataset by name, or the most recent one.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the dataset version.",
+			},
+			"dataset": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the dataset.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the version to look up. Defaults to latest, the most recently created version.",
+			},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the resolved version.",
+			},
+			"datums": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "IDs of the datums in the version.",
+			},
+			"uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "dataset:// URI of the version.",
+			},
+			"ctime": schema.StringAttribute{
+				Computed:    true,
+				Description: "Creation time of the version.",
+			},
+		},
+	}
+}
+
+func (d *datasetVersionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *datasetVersionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data datasetVersionDataModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	latest := name == "" || name == latestDatasetVersion
+	opts := ListOptions{
+		Filters:  map[string]string{"dataset": data.Dataset.ValueString()},
+		Ordering: "-ctime",
+	}
+	if latest {
+		opts.PageSize = 1
+		opts.MaxPages = 1
+	} else {
+		// The name filter is not guaranteed to be exact: keep paging until
+		// the version with this very name shows up.
+		opts.Filters["name"] = name
+	}
+
+	var found *datasetVersionAPI
+	err := ListEach(ctx, d.meta, "https://app.valohai.com/api/v0/dataset-versions/", opts, func(item json.RawMessage) (bool, error) {
+		var v datasetVersionAPI
+		if err := json.Unmarshal(item, &v); err != nil {
+			return false, fmt.Errorf("failed to decode dataset version: %w", err)
+		}
+		if !latest && v.Name != name {
+			return false, nil
+		}
+		found = &v
+		return true, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list dataset versions", err.Error())
+		return
+	}
+	if found == nil {
+		if name == "" {
+			name = latestDatasetVersion
+		}
+		resp.Diagnostics.AddError("Dataset version not found", fmt.Sprintf("dataset %s has no version %q", data.Dataset.ValueString(), name))
+		return
+	}
+
+	datums, diags := types.ListValueFrom(ctx, types.StringType, found.datumIDs())
+	resp.Diagnostics.Append(diags...)
+	data.ID = types.StringValue(found.ID)
+	data.Version = types.StringValue(found.Name)
+	data.Datums = datums
+	data.URI = optionalString(found.uri())
+	data.Ctime = types.StringValue(found.Ctime)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
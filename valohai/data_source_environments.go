@@ -0,0 +1,187 @@
+package valohai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type environmentsDataSource struct {
+	frameworkMeta
+}
+
+var _ datasource.DataSourceWithConfigure = &environmentsDataSource{}
+
+func newEnvironmentsDataSource() datasource.DataSource {
+	return &environmentsDataSource{}
+}
+
+// EnvironmentsDataSource returns the valohai_environments data source.
+func EnvironmentsDataSource() datasource.DataSource {
+	return newEnvironmentsDataSource()
+}
+
+// environmentsModel maps the valohai_environments schema.
+type environmentsModel struct {
+	ID           types.String       `tfsdk:"id"`
+	Owner        types.String       `tfsdk:"owner"`
+	GPU          types.Bool         `tfsdk:"gpu"`
+	NameRegex    types.String       `tfsdk:"name_regex"`
+	Enabled      types.Bool         `tfsdk:"enabled"`
+	Environments []environmentModel `tfsdk:"environments"`
+}
+
+type environmentModel struct {
+	ID              types.String  `tfsdk:"id"`
+	Slug            types.String  `tfsdk:"slug"`
+	Name            types.String  `tfsdk:"name"`
+	Enabled         types.Bool    `tfsdk:"enabled"`
+	GPU             types.Bool    `tfsdk:"gpu"`
+	GPUSpec         types.String  `tfsdk:"gpu_spec"`
+	PerHourPriceUSD types.Float64 `tfsdk:"per_hour_price_usd"`
+	QueueDepth      types.Int64   `tfsdk:"queue_depth"`
+	IsDefault       types.Bool    `tfsdk:"is_default"`
+}
+
+// environmentAPI is an environment as returned by the environments listing.
+type environmentAPI struct {
+	ID                 string   `json:"id"`
+	Slug               string   `json:"slug"`
+	Name               string   `json:"name"`
+	Enabled            bool     `json:"enabled"`
+	GPUSpec            string   `json:"gpu_spec"`
+	PerHourPriceUSD    *float64 `json:"per_hour_price_usd"`
+	UnfinishedJobCount int64    `json:"unfinished_job_count"`
+	IsDefault          bool     `json:"is_default"`
+}
+
+func (e environmentAPI) hasGPU() bool {
+	return e.GPUSpec != ""
+}
+
+func (d *environmentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environments"
+}
+
+func (d *environmentsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the execution environments (instance types, Kubernetes queues) available to the token.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier of the listing.",
+			},
+			"owner": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the environments of this organization (ID or name).",
+			},
+			"gpu": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list environments with (true) or without (false) GPUs.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list environments whose name matches this regular expression.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list enabled (true) or disabled (false) environments.",
+			},
+			"environments": schema.ListAttribute{
+				Computed:    true,
+				Description: "Matching environments, in the order returned by the API.",
+				ElementType: types.ObjectType{AttrTypes: map[string]attr.Type{
+					"id":                 types.StringType,
+					"slug":               types.StringType,
+					"name":               types.StringType,
+					"enabled":            types.BoolType,
+					"gpu":                types.BoolType,
+					"gpu_spec":           types.StringType,
+					"per_hour_price_usd": types.Float64Type,
+					"queue_depth":        types.Int64Type,
+					"is_default":         types.BoolType,
+				}},
+			},
+		},
+	}
+}
+
+func (d *environmentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *environmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data environmentsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name_regex", err.Error())
+			return
+		}
+		nameRegex = re
+	}
+
+	opts := ListOptions{Filters: map[string]string{}}
+	if !data.Owner.IsNull() {
+		opts.Filters["owner"] = data.Owner.ValueString()
+	}
+
+	data.Environments = []environmentModel{}
+	err := listEnvironments(ctx, d.meta, opts, func(e environmentAPI) (bool, error) {
+		if !data.GPU.IsNull() && e.hasGPU() != data.GPU.ValueBool() {
+			return false, nil
+		}
+		if !data.Enabled.IsNull() && e.Enabled != data.Enabled.ValueBool() {
+			return false, nil
+		}
+		if nameRegex != nil && !nameRegex.MatchString(e.Name) {
+			return false, nil
+		}
+		data.Environments = append(data.Environments, environmentModel{
+			ID:              types.StringValue(e.ID),
+			Slug:            types.StringValue(e.Slug),
+			Name:            types.StringValue(e.Name),
+			Enabled:         types.BoolValue(e.Enabled),
+			GPU:             types.BoolValue(e.hasGPU()),
+			GPUSpec:         types.StringValue(e.GPUSpec),
+			PerHourPriceUSD: types.Float64PointerValue(e.PerHourPriceUSD),
+			QueueDepth:      types.Int64Value(e.UnfinishedJobCount),
+			IsDefault:       types.BoolValue(e.IsDefault),
+		})
+		return false, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list Valohai environments", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue("environments")
+	if !data.Owner.IsNull() {
+		data.ID = types.StringValue("environments/" + data.Owner.ValueString())
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listEnvironments calls fn for every environment returned by the environments listing.
+func listEnvironments(ctx context.Context, m map[string]interface{}, opts ListOptions, fn func(environmentAPI) (bool, error)) error {
+	return ListEach(ctx, m, "https://app.valohai.com/api/v0/environments/", opts, func(item json.RawMessage) (bool, error) {
+		var e environmentAPI
+		if err := json.Unmarshal(item, &e); err != nil {
+			return false, fmt.Errorf("failed to decode environment: %w", err)
+		}
+		return fn(e)
+	})
+}
@@ -1,6 +1,7 @@
 package valohai
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,7 +11,7 @@ import (
 
 func dataSourceProject() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceProjectRead,
+		ReadContext: contextCRUD(dataSourceProjectRead),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -108,17 +109,17 @@ func dataSourceProject() *schema.Resource {
 	}
 }
 
-func dataSourceProjectRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	token := m.(map[string]interface{})["token"].(string)
 	id := d.Get("id").(string)
 	url := fmt.Sprintf("https://app.valohai.com/api/v0/projects/%s/", id)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create GET request: %w", err)
 	}
 	req.Header.Set("Authorization", "Token "+token)
-	client := &http.Client{}
+	client := httpClientFromMeta(m)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute GET request: %w", err)
@@ -1,6 +1,7 @@
 package valohai
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,7 +11,7 @@ import (
 
 func dataSourceTeam() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceTeamRead,
+		ReadContext: contextCRUD(dataSourceTeamRead),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -120,17 +121,17 @@ func dataSourceTeam() *schema.Resource {
 	}
 }
 
-func dataSourceTeamRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	token := m.(map[string]interface{})["token"].(string)
 	id := d.Get("id").(string)
 	url := fmt.Sprintf("https://app.valohai.com/api/v0/teams/%s/", id)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create GET request: %w", err)
 	}
 	req.Header.Set("Authorization", "Token "+token)
-	client := &http.Client{}
+	client := httpClientFromMeta(m)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute GET request: %w", err)
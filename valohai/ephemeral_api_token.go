@@ -0,0 +1,247 @@
+package valohai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultAPITokenTTL = time.Hour
+
+type apiTokenEphemeralResource struct {
+	frameworkMeta
+}
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &apiTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &apiTokenEphemeralResource{}
+)
+
+func newAPITokenEphemeralResource() ephemeral.EphemeralResource {
+	return &apiTokenEphemeralResource{}
+}
+
+// APITokenEphemeralResource returns the valohai_api_token ephemeral resource.
+func APITokenEphemeralResource() ephemeral.EphemeralResource {
+	return newAPITokenEphemeralResource()
+}
+
+// apiTokenModel maps the valohai_api_token schema.
+type apiTokenModel struct {
+	Name      types.String `tfsdk:"name"`
+	TTL       types.String `tfsdk:"ttl"`
+	Scopes    types.Set    `tfsdk:"scopes"`
+	ID        types.String `tfsdk:"id"`
+	Token     types.String `tfsdk:"token"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// apiTokenPrivate is kept in the ephemeral private data so Close can revoke the token.
+type apiTokenPrivate struct {
+	ID string `json:"id"`
+}
+
+func (r *apiTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (r *apiTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Short-lived Valohai API token, minted during plan/apply and revoked when Terraform is done with it. Never stored in state.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the token as shown in Valohai. Defaults to \"terraform-ephemeral\".",
+			},
+			"ttl": schema.StringAttribute{
+				Optional:    true,
+				Description: "Lifetime of the token as a Go duration, e.g. \"30m\". Defaults to \"1h\".",
+			},
+			"scopes": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Scopes granted to the token. Defaults to the scopes of the provider token.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the token, used to revoke it.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API token value.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "Expiry time of the token (RFC 3339).",
+			},
+		},
+	}
+}
+
+func (r *apiTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *apiTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.meta == nil {
+		resp.Diagnostics.AddError("Provider not configured", "the valohai provider must be configured before opening valohai_api_token")
+		return
+	}
+
+	var data apiTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := "terraform-ephemeral"
+	if !data.Name.IsNull() && data.Name.ValueString() != "" {
+		name = data.Name.ValueString()
+	}
+	ttl := defaultAPITokenTTL
+	if !data.TTL.IsNull() && data.TTL.ValueString() != "" {
+		d, err := time.ParseDuration(data.TTL.ValueString())
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid ttl", fmt.Sprintf("ttl must be a positive duration such as \"30m\", got %q", data.TTL.ValueString()))
+			return
+		}
+		ttl = d
+	}
+
+	payload := map[string]interface{}{
+		"name":       name,
+		"expires_at": time.Now().Add(ttl).UTC().Format(time.RFC3339),
+	}
+	if !data.Scopes.IsNull() {
+		var scopes []string
+		resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		payload["scopes"] = scopes
+	}
+
+	result, err := r.createToken(ctx, payload)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create Valohai API token", err.Error())
+		return
+	}
+
+	// Without the private data Close cannot revoke the token, so revoke it
+	// right away when anything fails from here on.
+	defer func() {
+		if !resp.Diagnostics.HasError() {
+			return
+		}
+		if err := r.revokeToken(ctx, result.ID); err != nil {
+			resp.Diagnostics.AddError("Failed to revoke Valohai API token", fmt.Sprintf("token %s could not be revoked after a failed open and expires at %s: %s", result.ID, result.ExpiresAt, err))
+		}
+	}()
+
+	data.ID = types.StringValue(result.ID)
+	data.Token = types.StringValue(result.Token)
+	data.ExpiresAt = types.StringValue(result.ExpiresAt)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	private, err := json.Marshal(apiTokenPrivate{ID: result.ID})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to encode private data", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, "token", private)...)
+}
+
+func (r *apiTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	if r.meta == nil {
+		return
+	}
+	raw, diags := req.Private.GetKey(ctx, "token")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+	var private apiTokenPrivate
+	if err := json.Unmarshal(raw, &private); err != nil {
+		resp.Diagnostics.AddError("Failed to decode private data", err.Error())
+		return
+	}
+	if err := r.revokeToken(ctx, private.ID); err != nil {
+		resp.Diagnostics.AddError("Failed to revoke Valohai API token", err.Error())
+	}
+}
+
+type apiTokenResult struct {
+	ID        string `json:"id"`
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+func (r *apiTokenEphemeralResource) createToken(ctx context.Context, payload map[string]interface{}) (*apiTokenResult, error) {
+	apiURL := "https://app.valohai.com/api/v0/tokens/"
+	authToken := r.meta["token"].(string)
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Token "+authToken)
+
+	resp, err := httpClientFromMeta(r.meta).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, parseAPIError(resp)
+	}
+
+	var result apiTokenResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.ID == "" || result.Token == "" {
+		return nil, fmt.Errorf("API response did not include a token id and value")
+	}
+	return &result, nil
+}
+
+func (r *apiTokenEphemeralResource) revokeToken(ctx context.Context, id string) error {
+	url := fmt.Sprintf("https://app.valohai.com/api/v0/tokens/%s/", id)
+	authToken := r.meta["token"].(string)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create DELETE request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+authToken)
+
+	resp, err := httpClientFromMeta(r.meta).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute DELETE request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK {
+		// 404 = already revoked or expired
+		return nil
+	}
+	return parseAPIError(resp)
+}
@@ -0,0 +1,81 @@
+package valohai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+const datumURIPrefix = "datum://"
+
+// DatumURI returns the datum:// URI of a datum id or datum alias.
+// A value that already carries the datum:// prefix is returned normalized.
+func DatumURI(datum string) (string, error) {
+	name := strings.TrimPrefix(strings.TrimSpace(datum), datumURIPrefix)
+	if name == "" {
+		return "", fmt.Errorf("datum id or alias must not be empty")
+	}
+	if strings.ContainsAny(name, "/ \t\n") {
+		return "", fmt.Errorf("invalid datum id or alias %q: must not contain slashes or whitespace", datum)
+	}
+	return datumURIPrefix + name, nil
+}
+
+// datumID returns the id of a datum given as an id or a datum:// URI. Datum
+// aliases are rejected: Valohai reports the datums of other objects by id, so
+// an alias could never be matched against what it points to.
+func datumID(datum string) (string, error) {
+	uri, err := DatumURI(datum)
+	if err != nil {
+		return "", err
+	}
+	id := strings.TrimPrefix(uri, datumURIPrefix)
+	if uuid.Validate(id) != nil {
+		return "", fmt.Errorf("%q is not a datum id: datum aliases are not accepted, use the id of the datum instead", datum)
+	}
+	return id, nil
+}
+
+type datumURIFunction struct{}
+
+var _ function.Function = &datumURIFunction{}
+
+func newDatumURIFunction() function.Function {
+	return &datumURIFunction{}
+}
+
+func (f *datumURIFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "datum_uri"
+}
+
+func (f *datumURIFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a datum:// URI",
+		Description: "Returns the datum:// URI referencing a datum id or datum alias, for use as an execution or pipeline input.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "datum",
+				Description: "Datum id or datum alias.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *datumURIFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var datum string
+	resp.Error = req.Arguments.Get(ctx, &datum)
+	if resp.Error != nil {
+		return
+	}
+
+	uri, err := DatumURI(datum)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, uri)
+}
@@ -0,0 +1,68 @@
+package valohai
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// ImageMatchesPattern reports whether a Docker image matches a registry
+// credentials image_pattern. "*" matches any sequence of characters,
+// including slashes, and "?" matches a single character.
+func ImageMatchesPattern(image, pattern string) bool {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String()).MatchString(image)
+}
+
+type imageMatchesPatternFunction struct{}
+
+var _ function.Function = &imageMatchesPatternFunction{}
+
+func newImageMatchesPatternFunction() function.Function {
+	return &imageMatchesPatternFunction{}
+}
+
+func (f *imageMatchesPatternFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "image_matches_pattern"
+}
+
+func (f *imageMatchesPatternFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check an image against a registry credentials pattern",
+		Description: "Returns true when the Docker image matches the image_pattern of a valohai_registry_credentials resource.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "image",
+				Description: "Docker image, e.g. docker.io/library/python:3.12.",
+			},
+			function.StringParameter{
+				Name:        "pattern",
+				Description: "Image pattern, e.g. docker.io/*.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *imageMatchesPatternFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var image, pattern string
+	resp.Error = req.Arguments.Get(ctx, &image, &pattern)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, ImageMatchesPattern(image, pattern))
+}
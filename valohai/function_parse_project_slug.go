@@ -0,0 +1,71 @@
+package valohai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ParseProjectSlug splits an "owner/project" slug into its owner and project name.
+func ParseProjectSlug(slug string) (owner, project string, err error) {
+	parts := strings.Split(strings.TrimSpace(slug), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid project slug %q: expected \"owner/project\"", slug)
+	}
+	return parts[0], parts[1], nil
+}
+
+type parseProjectSlugFunction struct{}
+
+var _ function.Function = &parseProjectSlugFunction{}
+
+func newParseProjectSlugFunction() function.Function {
+	return &parseProjectSlugFunction{}
+}
+
+func (f *parseProjectSlugFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_project_slug"
+}
+
+func (f *parseProjectSlugFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse a Valohai project slug",
+		Description: "Splits a project slug such as \"my-org/my-project\" into an object with owner and project attributes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "slug",
+				Description: "Project slug in the form owner/project.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"owner":   types.StringType,
+				"project": types.StringType,
+			},
+		},
+	}
+}
+
+func (f *parseProjectSlugFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var slug string
+	resp.Error = req.Arguments.Get(ctx, &slug)
+	if resp.Error != nil {
+		return
+	}
+
+	owner, project, err := ParseProjectSlug(slug)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result := struct {
+		Owner   string `tfsdk:"owner"`
+		Project string `tfsdk:"project"`
+	}{Owner: owner, Project: project}
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -0,0 +1,88 @@
+package valohai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// storeURISchemes maps valohai_store types to the URI scheme used by Valohai.
+var storeURISchemes = map[string]string{
+	"s3":     "s3",
+	"google": "gs",
+	"azure":  "azure",
+	"swift":  "swift",
+}
+
+// StoreURI returns the URI of a path inside a store bucket, e.g. s3://bucket/data/input.
+func StoreURI(storeType, bucket, path string) (string, error) {
+	scheme, ok := storeURISchemes[storeType]
+	if !ok {
+		return "", fmt.Errorf("unsupported store type %q (allowed: s3, swift, azure, google)", storeType)
+	}
+	bucket = strings.Trim(strings.TrimSpace(bucket), "/")
+	if bucket == "" {
+		return "", fmt.Errorf("bucket must not be empty")
+	}
+	uri := scheme + "://" + bucket
+	if path = strings.Trim(strings.TrimSpace(path), "/"); path != "" {
+		uri += "/" + path
+	}
+	return uri, nil
+}
+
+type storeURIFunction struct{}
+
+var _ function.Function = &storeURIFunction{}
+
+func newStoreURIFunction() function.Function {
+	return &storeURIFunction{}
+}
+
+func (f *storeURIFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "store_uri"
+}
+
+func (f *storeURIFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a store URI",
+		Description: "Returns the URI of a path inside a store bucket, using the scheme matching the valohai_store type (s3://, gs://, azure://, swift://).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "type",
+				Description: "Store type: s3, swift, azure or google.",
+			},
+			function.StringParameter{
+				Name:        "bucket",
+				Description: "Bucket or container name.",
+			},
+			function.StringParameter{
+				Name:        "path",
+				Description: "Path inside the bucket; may be empty.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *storeURIFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var storeType, bucket, path string
+	resp.Error = req.Arguments.Get(ctx, &storeType, &bucket, &path)
+	if resp.Error != nil {
+		return
+	}
+
+	uri, err := StoreURI(storeType, bucket, path)
+	if err != nil {
+		// Only the type and the bucket can be invalid
+		var arg int64 = 1
+		if _, ok := storeURISchemes[storeType]; !ok {
+			arg = 0
+		}
+		resp.Error = function.NewArgumentFuncError(arg, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, uri)
+}
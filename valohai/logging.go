@@ -0,0 +1,174 @@
+package valohai
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	requestIDHeader = "X-Request-Id"
+	redactedValue   = "***"
+)
+
+// sensitiveBodyFields lists the JSON keys whose values are never written to the logs.
+var sensitiveBodyFields = map[string]bool{
+	"secret_access_key":    true,
+	"password":             true,
+	"service_account_json": true,
+	"token":                true,
+	"secret":               true,
+}
+
+// sensitiveNestedFields lists keys that are only secret inside a given object,
+// e.g. notification channel URLs, which let anyone holding them post to the
+// channel, while other "url" fields are plain API links.
+var sensitiveNestedFields = map[string]map[string]bool{
+	"config": {"url": true},
+}
+
+// loggingTransport emits one tflog entry per API request and response.
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+func newLoggingTransport(base http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{base: base}
+}
+
+// debugLogging reports whether provider logs are kept at DEBUG or TRACE level,
+// reading the variables Terraform uses for the provider log level. Terraform
+// drops provider logs below that level, so bodies are only captured then.
+func debugLogging() bool {
+	for _, name := range []string{"TF_LOG_PROVIDER_VALOHAI", "TF_LOG_PROVIDER", "TF_LOG"} {
+		if level := strings.ToUpper(strings.TrimSpace(os.Getenv(name))); level != "" {
+			// TF_LOG=JSON logs at TRACE level in JSON format
+			return level == "TRACE" || level == "DEBUG" || level == "JSON"
+		}
+	}
+	return false
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !debugLogging() {
+		return t.base.RoundTrip(req)
+	}
+	ctx := req.Context()
+
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.String(),
+	}
+
+	reqFields := map[string]interface{}{
+		"headers": redactHeaders(req.Header),
+	}
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			body.Close()
+			reqFields["body"] = RedactJSON(b)
+		}
+	}
+	tflog.Debug(ctx, "Sending Valohai API request", mergeFields(fields, reqFields))
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Valohai API request failed", fields)
+		return nil, err
+	}
+
+	// The request id assigned by the API, to match the logs with Valohai support
+	if id := resp.Header.Get(requestIDHeader); id != "" {
+		fields["request_id"] = id
+	}
+	fields["status"] = resp.StatusCode
+
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+
+	tflog.Debug(ctx, "Received Valohai API response", mergeFields(fields, map[string]interface{}{
+		"body": RedactJSON(b),
+	}))
+	return resp, nil
+}
+
+func mergeFields(a, b map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(a)+len(b))
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		out[k] = v
+	}
+	return out
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k := range h {
+		if http.CanonicalHeaderKey(k) == "Authorization" {
+			out[k] = redactedValue
+			continue
+		}
+		out[k] = h.Get(k)
+	}
+	return out
+}
+
+// RedactJSON returns the body as a string with the values of known secret fields masked.
+// Bodies that are not valid JSON are returned unchanged.
+func RedactJSON(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if sensitiveBodyFields[k] {
+				t[k] = redactedValue
+				continue
+			}
+			if nested, ok := val.(map[string]interface{}); ok {
+				for field := range sensitiveNestedFields[k] {
+					if _, ok := nested[field]; ok {
+						nested[field] = redactedValue
+					}
+				}
+			}
+			t[k] = redactValue(val)
+		}
+		return t
+	case []interface{}:
+		for i, val := range t {
+			t[i] = redactValue(val)
+		}
+		return t
+	}
+	return v
+}
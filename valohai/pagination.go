@@ -0,0 +1,126 @@
+package valohai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// defaultMaxPages bounds how many pages a single listing may fetch.
+const defaultMaxPages = 100
+
+// ListOptions controls how a Valohai list endpoint is queried.
+type ListOptions struct {
+	// Filters are sent as query parameters, e.g. {"owner": "42", "name": "foo"}.
+	Filters map[string]string
+	// Ordering is the DRF ordering parameter, e.g. "-ctime".
+	Ordering string
+	// PageSize is sent as "limit"; 0 keeps the API default.
+	PageSize int
+	// MaxPages aborts the listing after this many pages; 0 uses defaultMaxPages.
+	MaxPages int
+}
+
+// listPage mirrors the envelope returned by Valohai list endpoints.
+type listPage struct {
+	Count    int               `json:"count"`
+	Next     *string           `json:"next"`
+	Previous *string           `json:"previous"`
+	Results  []json.RawMessage `json:"results"`
+}
+
+// ListEach walks a paginated Valohai list endpoint, following "next" links and
+// calling fn for every result. Returning true from fn stops the iteration early.
+// The meta argument is the value returned by the provider configure function.
+func ListEach(ctx context.Context, m interface{}, endpoint string, opts ListOptions, fn func(item json.RawMessage) (bool, error)) error {
+	authToken := m.(map[string]interface{})["token"].(string)
+	client := httpClientFromMeta(m)
+
+	pageURL, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid list endpoint %q: %w", endpoint, err)
+	}
+	q := pageURL.Query()
+	for k, v := range opts.Filters {
+		q.Set(k, v)
+	}
+	if opts.Ordering != "" {
+		q.Set("ordering", opts.Ordering)
+	}
+	if opts.PageSize > 0 {
+		q.Set("limit", strconv.Itoa(opts.PageSize))
+	}
+	pageURL.RawQuery = q.Encode()
+
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+
+	seen := map[string]bool{}
+	for page := 1; ; page++ {
+		if page > maxPages {
+			return fmt.Errorf("listing %s exceeded %d pages", endpoint, maxPages)
+		}
+		current := pageURL.String()
+		if seen[current] {
+			return fmt.Errorf("listing %s returned a pagination loop at %s", endpoint, current)
+		}
+		seen[current] = true
+
+		result, err := fetchListPage(ctx, client, authToken, current)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Results {
+			stop, err := fn(item)
+			if err != nil {
+				return err
+			}
+			if stop {
+				return nil
+			}
+		}
+
+		if result.Next == nil || *result.Next == "" {
+			return nil
+		}
+		next, err := pageURL.Parse(*result.Next)
+		if err != nil {
+			return fmt.Errorf("invalid next link %q: %w", *result.Next, err)
+		}
+		// Never send the token to another host, or in plaintext
+		if next.Host != pageURL.Host {
+			return fmt.Errorf("refusing to follow next link to another host: %s", next.Host)
+		}
+		if next.Scheme != pageURL.Scheme {
+			return fmt.Errorf("refusing to follow next link from %s to %s", pageURL.Scheme, next.Scheme)
+		}
+		pageURL = next
+	}
+}
+
+func fetchListPage(ctx context.Context, client *http.Client, authToken, pageURL string) (*listPage, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GET request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+authToken)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute GET request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseAPIError(resp)
+	}
+
+	var result listPage
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode list response: %w", err)
+	}
+	return &result, nil
+}
@@ -6,10 +6,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // configureProvider configures the provider.
-func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func configureProvider(ctx context.Context, d *schema.ResourceData, version, terraformVersion string) (interface{}, diag.Diagnostics) {
 	_ = ctx
 	// Retrieve the token from the configuration
 	authToken := d.Get("token").(string)
@@ -21,14 +22,53 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}
 		return nil, diag.Errorf("valohai provider token is required: set token in provider config or VALOHAI_API_TOKEN env var")
 	}
 
-	// Return an object containing the token for use in resources
+	// Build the HTTP client shared by every resource and data source
+	client, err := newHTTPClient(httpClientConfig{
+		ProxyURL:           d.Get("proxy_url").(string),
+		CACertFile:         d.Get("ca_cert_file").(string),
+		CACertPEM:          d.Get("ca_cert_pem").(string),
+		ClientCertPEM:      d.Get("client_cert").(string),
+		ClientKeyPEM:       d.Get("client_key").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+
+		RequestsPerSecond:     d.Get("requests_per_second").(float64),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+
+		UserAgent: userAgent(version, terraformVersion, d.Get("user_agent_suffix").(string)),
+	})
+	if err != nil {
+		return nil, diag.Errorf("failed to configure valohai HTTP client: %s", err)
+	}
+
+	// Return an object containing the token and client for use in resources
 	return map[string]interface{}{
-		"token": authToken,
+		"token":       authToken,
+		"http_client": client,
 	}, nil
 }
 
+// contextCRUD adapts an error-returning CRUD function to the SDK context-aware signature,
+// so the request context (and its tflog logger) reaches the HTTP layer.
+func contextCRUD(f func(context.Context, *schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return diag.FromErr(f(ctx, d, m))
+	}
+}
+
+// Provider returns the provider with a development version stamp.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	return newProvider("dev")
+}
+
+// New returns a provider factory reporting the given release version in its User-Agent.
+func New(version string) func() *schema.Provider {
+	return func() *schema.Provider {
+		return newProvider(version)
+	}
+}
+
+func newProvider(version string) *schema.Provider {
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"token": {
 				Type:        schema.TypeString,
@@ -37,6 +77,62 @@ func Provider() *schema.Provider {
 				Description: "Valohai API token.",
 				Sensitive:   true,
 			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VALOHAI_PROXY_URL", ""),
+				Description: "URL of the HTTP(S) proxy used to reach the Valohai API. Defaults to HTTPS_PROXY/HTTP_PROXY.",
+			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VALOHAI_CA_CERT_FILE", ""),
+				Description: "Path to a PEM-encoded CA bundle trusted in addition to the system roots. Ignored when ca_cert_pem is set.",
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM-encoded CA bundle trusted in addition to the system roots. Takes precedence over ca_cert_file.",
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+				Description:  "PEM-encoded client certificate for mutual TLS.",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_cert"},
+				Description:  "PEM-encoded private key of the client certificate.",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip TLS certificate verification. Only use for testing.",
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of API requests per second, shared by all resources. 0 disables rate limiting.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests in flight at once, shared by all resources. 0 disables the limit.",
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VALOHAI_USER_AGENT_SUFFIX", ""),
+				Description: "Text appended to the User-Agent header, e.g. to tag the calling pipeline.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -51,7 +147,11 @@ func Provider() *schema.Provider {
 			"valohai_team":    dataSourceTeam(),
 			"valohai_store":   dataSourceStore(),
 		},
+	}
 
-		ConfigureContextFunc: configureProvider,
-	}
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		// TerraformVersion is filled in by the SDK before configure is called
+		return configureProvider(ctx, d, version, p.TerraformVersion)
+	}
+	return p
 }
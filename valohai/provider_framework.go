@@ -0,0 +1,146 @@
+package valohai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServer returns the muxed provider server serving both the
+// terraform-plugin-sdk/v2 resources and the terraform-plugin-framework ones.
+func ProviderServer(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	sdkProvider := newProvider(version)
+
+	// The SDK server must stay first: the mux configures servers in order and
+	// the framework provider reuses the meta configured by the SDK provider.
+	providers := []func() tfprotov5.ProviderServer{
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(newFrameworkProvider(version, sdkProvider)),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProvider hosts the resources written on terraform-plugin-framework.
+type frameworkProvider struct {
+	version string
+	sdk     *schema.Provider
+}
+
+var (
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+)
+
+func newFrameworkProvider(version string, sdk *schema.Provider) provider.Provider {
+	return &frameworkProvider{version: version, sdk: sdk}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "valohai"
+	resp.Version = p.version
+}
+
+// Schema mirrors the SDK provider schema, which the mux server requires to be identical.
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	attrs := map[string]providerschema.Attribute{}
+	for name, s := range p.sdk.Schema {
+		switch s.Type {
+		case schema.TypeString:
+			attrs[name] = providerschema.StringAttribute{Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive, Description: s.Description}
+		case schema.TypeBool:
+			attrs[name] = providerschema.BoolAttribute{Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive, Description: s.Description}
+		case schema.TypeInt:
+			attrs[name] = providerschema.Int64Attribute{Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive, Description: s.Description}
+		case schema.TypeFloat:
+			attrs[name] = providerschema.Float64Attribute{Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive, Description: s.Description}
+		default:
+			resp.Diagnostics.AddError("Unsupported provider attribute", fmt.Sprintf("provider attribute %q has unsupported type %s", name, s.Type))
+		}
+	}
+	resp.Schema = providerschema.Schema{Attributes: attrs}
+}
+
+// Configure hands the meta built by the SDK provider to framework resources,
+// so both halves share the same token and HTTP client.
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	meta, ok := p.sdk.Meta().(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError("Provider not configured", "the valohai provider must be configured before framework resources can be used")
+		return
+	}
+	resp.ResourceData = meta
+	resp.DataSourceData = meta
+	resp.EphemeralResourceData = meta
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newProjectStoreBindingResource,
+		newDeploymentResource,
+		newDeploymentVersionResource,
+		newDeploymentAliasResource,
+		newTriggerResource,
+		newNotificationChannelResource,
+		newNotificationRoutingResource,
+		newDatasetResource,
+		newDatasetVersionResource,
+		newDatumAliasResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newEnvironmentsDataSource,
+		newDatasetVersionDataSource,
+	}
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newAPITokenEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		newParseProjectSlugFunction,
+		newDatumURIFunction,
+		newImageMatchesPatternFunction,
+		newStoreURIFunction,
+	}
+}
+
+// frameworkMeta is embedded by framework resources to receive the provider
+// meta (token and shared HTTP client) built by the SDK provider.
+type frameworkMeta struct {
+	meta map[string]interface{}
+}
+
+func (f *frameworkMeta) configure(providerData any, diags *diag.Diagnostics) {
+	// ProviderData is nil until the provider has been configured
+	if providerData == nil {
+		return
+	}
+	meta, ok := providerData.(map[string]interface{})
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("expected map[string]interface{}, got %T", providerData))
+		return
+	}
+	f.meta = meta
+}
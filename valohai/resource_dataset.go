@@ -0,0 +1,210 @@
+package valohai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type datasetResource struct {
+	frameworkMeta
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &datasetResource{}
+	_ resource.ResourceWithImportState    = &datasetResource{}
+	_ resource.ResourceWithValidateConfig = &datasetResource{}
+)
+
+func newDatasetResource() resource.Resource {
+	return &datasetResource{}
+}
+
+// DatasetResource returns the valohai_dataset resource.
+func DatasetResource() resource.Resource {
+	return newDatasetResource()
+}
+
+const datasetURIPrefix = "dataset://"
+
+// datasetAccessModes are the values accepted for access.
+var datasetAccessModes = []string{"project", "organization"}
+
+// datasetModel maps the valohai_dataset schema.
+type datasetModel struct {
+	ID      types.String `tfsdk:"id"`
+	Project types.String `tfsdk:"project"`
+	Name    types.String `tfsdk:"name"`
+	Access  types.String `tfsdk:"access"`
+	URI     types.String `tfsdk:"uri"`
+}
+
+// datasetAPI is a dataset as returned by the API.
+type datasetAPI struct {
+	ID         string      `json:"id"`
+	Project    interface{} `json:"project"`
+	Name       string      `json:"name"`
+	AccessMode string      `json:"access_mode"`
+}
+
+func (r *datasetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset"
+}
+
+func (r *datasetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Valohai dataset, a named collection of versioned sets of datums.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the dataset.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project owning the dataset.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the dataset, part of its dataset:// URIs.",
+			},
+			"access": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("project"),
+				Description: "Who can use the dataset: project (members of the owning project) or organization (every project of the organization). Defaults to project.",
+			},
+			"uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "dataset:// URI of the dataset.",
+			},
+		},
+	}
+}
+
+func (r *datasetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *datasetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var access types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("access"), &access)...)
+	if access.IsNull() || access.IsUnknown() {
+		return
+	}
+	for _, mode := range datasetAccessModes {
+		if access.ValueString() == mode {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("access"), "Invalid access", fmt.Sprintf("expected project or organization, got %q", access.ValueString()))
+}
+
+func datasetURL(id string) string {
+	return fmt.Sprintf("https://app.valohai.com/api/v0/datasets/%s/", id)
+}
+
+func (data *datasetModel) setFromAPI(result datasetAPI) {
+	data.ID = types.StringValue(result.ID)
+	data.Project = types.StringValue(apiID(result.Project))
+	data.Name = types.StringValue(result.Name)
+	data.Access = types.StringValue(result.AccessMode)
+	data.URI = types.StringValue(datasetURIPrefix + result.Name)
+}
+
+func (r *datasetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data datasetModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result datasetAPI
+	err := doJSON(ctx, r.meta, http.MethodPost, "https://app.valohai.com/api/v0/datasets/", map[string]interface{}{
+		"project":     data.Project.ValueString(),
+		"name":        data.Name.ValueString(),
+		"access_mode": data.Access.ValueString(),
+	}, &result)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create dataset", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(result.ID)
+	data.URI = types.StringValue(datasetURIPrefix + data.Name.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *datasetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data datasetModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result datasetAPI
+	err := doJSON(ctx, r.meta, http.MethodGet, datasetURL(data.ID.ValueString()), nil, &result)
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read dataset", err.Error())
+		return
+	}
+
+	data.setFromAPI(result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *datasetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data datasetModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result datasetAPI
+	err := doJSON(ctx, r.meta, http.MethodPatch, datasetURL(data.ID.ValueString()), map[string]interface{}{
+		"name":        data.Name.ValueString(),
+		"access_mode": data.Access.ValueString(),
+	}, &result)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update dataset", err.Error())
+		return
+	}
+
+	data.URI = types.StringValue(datasetURIPrefix + data.Name.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *datasetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data datasetModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodDelete, datasetURL(data.ID.ValueString()), nil, nil)
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Failed to delete dataset", err.Error())
+	}
+}
+
+func (r *datasetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
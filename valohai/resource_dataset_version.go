@@ -0,0 +1,270 @@
+package valohai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type datasetVersionResource struct {
+	frameworkMeta
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &datasetVersionResource{}
+	_ resource.ResourceWithImportState    = &datasetVersionResource{}
+	_ resource.ResourceWithValidateConfig = &datasetVersionResource{}
+)
+
+func newDatasetVersionResource() resource.Resource {
+	return &datasetVersionResource{}
+}
+
+// DatasetVersionResource returns the valohai_dataset_version resource.
+func DatasetVersionResource() resource.Resource {
+	return newDatasetVersionResource()
+}
+
+// datasetVersionModel maps the valohai_dataset_version schema.
+type datasetVersionModel struct {
+	ID      types.String `tfsdk:"id"`
+	Dataset types.String `tfsdk:"dataset"`
+	Name    types.String `tfsdk:"name"`
+	Datums  types.List   `tfsdk:"datums"`
+	URI     types.String `tfsdk:"uri"`
+}
+
+// datasetVersionAPI is a dataset version as returned by the API.
+type datasetVersionAPI struct {
+	ID      string      `json:"id"`
+	Name    string      `json:"name"`
+	Ctime   string      `json:"ctime"`
+	Dataset interface{} `json:"dataset"`
+	Files   []struct {
+		Datum interface{} `json:"datum"`
+	} `json:"files"`
+}
+
+// uri returns the dataset:// URI of the version, or "" when the API gave the
+// dataset as a bare id without its name.
+func (v datasetVersionAPI) uri() string {
+	dataset, _ := v.Dataset.(map[string]interface{})
+	name, _ := dataset["name"].(string)
+	if name == "" {
+		return ""
+	}
+	return datasetURIPrefix + name + "/" + v.Name
+}
+
+// datumIDs returns the ids of the datums of the version, in API order.
+func (v datasetVersionAPI) datumIDs() []string {
+	ids := make([]string, 0, len(v.Files))
+	for _, f := range v.Files {
+		ids = append(ids, apiID(f.Datum))
+	}
+	return ids
+}
+
+// sameDatums reports whether the configured datums, given as ids or datum://
+// URIs, are exactly the datums of the version.
+func sameDatums(configured, ids []string) bool {
+	if len(configured) != len(ids) {
+		return false
+	}
+	a := make([]string, len(configured))
+	for i, d := range configured {
+		a[i] = strings.TrimPrefix(d, datumURIPrefix)
+	}
+	b := append([]string(nil), ids...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *datasetVersionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset_version"
+}
+
+func (r *datasetVersionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Immutable version of a Valohai dataset. Any change creates a new version.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the dataset version.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dataset": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the dataset.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the version, unique within the dataset.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"datums": schema.ListAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Datums in the version, as datum ids or datum://<id> URIs. Datum aliases are not accepted.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "dataset:// URI of the version, for use as an execution or pipeline input.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *datasetVersionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *datasetVersionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data datasetVersionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Datums.IsUnknown() {
+		return
+	}
+
+	var datums []types.String
+	resp.Diagnostics.Append(data.Datums.ElementsAs(ctx, &datums, false)...)
+	if len(datums) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("datums"), "Missing datums", "a dataset version needs at least one datum")
+	}
+	for i, d := range datums {
+		if d.IsUnknown() {
+			continue
+		}
+		if _, err := datumID(d.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("datums").AtListIndex(i), "Invalid datum", err.Error())
+		}
+	}
+}
+
+func datasetVersionURL(id string) string {
+	return fmt.Sprintf("https://app.valohai.com/api/v0/dataset-versions/%s/", id)
+}
+
+func (r *datasetVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data datasetVersionModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var datums []string
+	resp.Diagnostics.Append(data.Datums.ElementsAs(ctx, &datums, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	files := make([]interface{}, 0, len(datums))
+	for _, d := range datums {
+		files = append(files, map[string]interface{}{"datum": strings.TrimPrefix(d, datumURIPrefix)})
+	}
+
+	var result datasetVersionAPI
+	err := doJSON(ctx, r.meta, http.MethodPost, "https://app.valohai.com/api/v0/dataset-versions/", map[string]interface{}{
+		"dataset": data.Dataset.ValueString(),
+		"name":    data.Name.ValueString(),
+		"files":   files,
+	}, &result)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create dataset version", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(result.ID)
+	data.URI = optionalString(result.uri())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the datums as configured, ids or URIs, unless the version holds
+// different datums.
+func (r *datasetVersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data datasetVersionModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result datasetVersionAPI
+	err := doJSON(ctx, r.meta, http.MethodGet, datasetVersionURL(data.ID.ValueString()), nil, &result)
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read dataset version", err.Error())
+		return
+	}
+
+	var datums []string
+	if !data.Datums.IsNull() {
+		resp.Diagnostics.Append(data.Datums.ElementsAs(ctx, &datums, false)...)
+	}
+	if ids := result.datumIDs(); data.Datums.IsNull() || !sameDatums(datums, ids) {
+		list, diags := types.ListValueFrom(ctx, types.StringType, ids)
+		resp.Diagnostics.Append(diags...)
+		data.Datums = list
+	}
+	data.Dataset = types.StringValue(apiID(result.Dataset))
+	data.Name = types.StringValue(result.Name)
+	if uri := result.uri(); uri != "" {
+		data.URI = types.StringValue(uri)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never called with changes: every argument forces replacement.
+func (r *datasetVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data datasetVersionModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *datasetVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data datasetVersionModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodDelete, datasetVersionURL(data.ID.ValueString()), nil, nil)
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Failed to delete dataset version", err.Error())
+	}
+}
+
+func (r *datasetVersionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,212 @@
+package valohai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type datumAliasResource struct {
+	frameworkMeta
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &datumAliasResource{}
+	_ resource.ResourceWithImportState    = &datumAliasResource{}
+	_ resource.ResourceWithValidateConfig = &datumAliasResource{}
+)
+
+func newDatumAliasResource() resource.Resource {
+	return &datumAliasResource{}
+}
+
+// DatumAliasResource returns the valohai_datum_alias resource.
+func DatumAliasResource() resource.Resource {
+	return newDatumAliasResource()
+}
+
+// datumAliasModel maps the valohai_datum_alias schema.
+type datumAliasModel struct {
+	ID      types.String `tfsdk:"id"`
+	Project types.String `tfsdk:"project"`
+	Name    types.String `tfsdk:"name"`
+	Datum   types.String `tfsdk:"datum"`
+	URI     types.String `tfsdk:"uri"`
+}
+
+// datumAliasAPI is a datum alias as returned by the API.
+type datumAliasAPI struct {
+	ID      string      `json:"id"`
+	Project interface{} `json:"project"`
+	Name    string      `json:"name"`
+	Datum   interface{} `json:"datum"`
+}
+
+func (r *datumAliasResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datum_alias"
+}
+
+func (r *datumAliasResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Valohai datum alias, a stable datum:// name pointing at one datum of a project.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the alias.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project the alias belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the alias, referenced as datum://<name>.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"datum": schema.StringAttribute{
+				Required:    true,
+				Description: "Datum the alias points at, as a datum id or datum:// URI. Changing it repoints the alias.",
+			},
+			"uri": schema.StringAttribute{
+				Computed:    true,
+				Description: "datum:// URI of the alias.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *datumAliasResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *datumAliasResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data datumAliasModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Name.IsUnknown() && !data.Name.IsNull() {
+		if strings.HasPrefix(data.Name.ValueString(), datumURIPrefix) {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid alias name", "name is the bare alias name, without the datum:// prefix")
+		} else if _, err := DatumURI(data.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Invalid alias name", err.Error())
+		}
+	}
+	if !data.Datum.IsUnknown() && !data.Datum.IsNull() {
+		// An alias target would be read back as the datum id and repointed on every apply
+		if _, err := datumID(data.Datum.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("datum"), "Invalid datum", err.Error())
+		}
+	}
+}
+
+func datumAliasURL(id string) string {
+	return fmt.Sprintf("https://app.valohai.com/api/v0/datum-aliases/%s/", id)
+}
+
+func (r *datumAliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data datumAliasModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result datumAliasAPI
+	err := doJSON(ctx, r.meta, http.MethodPost, "https://app.valohai.com/api/v0/datum-aliases/", map[string]interface{}{
+		"project": data.Project.ValueString(),
+		"name":    data.Name.ValueString(),
+		"datum":   strings.TrimPrefix(data.Datum.ValueString(), datumURIPrefix),
+	}, &result)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create datum alias", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(result.ID)
+	data.URI = types.StringValue(datumURIPrefix + data.Name.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read reports an alias repointed outside Terraform as drift on datum, keeping
+// the configured form (id or URI) while it still points at the same datum.
+func (r *datumAliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data datumAliasModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result datumAliasAPI
+	err := doJSON(ctx, r.meta, http.MethodGet, datumAliasURL(data.ID.ValueString()), nil, &result)
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read datum alias", err.Error())
+		return
+	}
+
+	if datum := apiID(result.Datum); strings.TrimPrefix(data.Datum.ValueString(), datumURIPrefix) != datum {
+		data.Datum = types.StringValue(datum)
+	}
+	data.Project = types.StringValue(apiID(result.Project))
+	data.Name = types.StringValue(result.Name)
+	data.URI = types.StringValue(datumURIPrefix + result.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *datumAliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data datumAliasModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodPatch, datumAliasURL(data.ID.ValueString()), map[string]interface{}{
+		"datum": strings.TrimPrefix(data.Datum.ValueString(), datumURIPrefix),
+	}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to repoint datum alias", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *datumAliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data datumAliasModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodDelete, datumAliasURL(data.ID.ValueString()), nil, nil)
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Failed to delete datum alias", err.Error())
+	}
+}
+
+func (r *datumAliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
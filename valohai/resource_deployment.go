@@ -0,0 +1,211 @@
+package valohai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type deploymentResource struct {
+	frameworkMeta
+}
+
+var (
+	_ resource.ResourceWithConfigure   = &deploymentResource{}
+	_ resource.ResourceWithImportState = &deploymentResource{}
+)
+
+func newDeploymentResource() resource.Resource {
+	return &deploymentResource{}
+}
+
+// DeploymentResource returns the valohai_deployment resource.
+func DeploymentResource() resource.Resource {
+	return newDeploymentResource()
+}
+
+// deploymentModel maps the valohai_deployment schema.
+type deploymentModel struct {
+	ID      types.String `tfsdk:"id"`
+	Project types.String `tfsdk:"project"`
+	Name    types.String `tfsdk:"name"`
+	Target  types.String `tfsdk:"target"`
+	URL     types.String `tfsdk:"url"`
+	Aliases types.Set    `tfsdk:"aliases"`
+}
+
+// deploymentAPI is a deployment as returned by the API.
+type deploymentAPI struct {
+	ID      string      `json:"id"`
+	Name    string      `json:"name"`
+	Project interface{} `json:"project"`
+	Target  interface{} `json:"target"`
+	URL     string      `json:"url"`
+	Aliases []struct {
+		Name string `json:"name"`
+	} `json:"aliases"`
+}
+
+func (r *deploymentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment"
+}
+
+func (r *deploymentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Valohai deployment serving inference endpoints of a project on a deployment target.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the deployment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project the deployment belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the deployment, part of the endpoint URLs.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the deployment target (Kubernetes cluster) the deployment runs on.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Computed:    true,
+				Description: "API URL of the deployment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"aliases": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Names of the version aliases of the deployment. Read-only: an alias must point at a version, so aliases are managed with valohai_deployment_alias.",
+			},
+		},
+	}
+}
+
+func (r *deploymentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func deploymentURL(id string) string {
+	return fmt.Sprintf("https://app.valohai.com/api/v0/deployments/%s/", id)
+}
+
+func (r *deploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data deploymentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result deploymentAPI
+	err := doJSON(ctx, r.meta, http.MethodPost, "https://app.valohai.com/api/v0/deployments/", map[string]interface{}{
+		"project": data.Project.ValueString(),
+		"name":    data.Name.ValueString(),
+		"target":  data.Target.ValueString(),
+	}, &result)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create deployment", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.setComputed(ctx, &data, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *deploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data deploymentModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result deploymentAPI
+	err := doJSON(ctx, r.meta, http.MethodGet, deploymentURL(data.ID.ValueString()), nil, &result)
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read deployment", err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(result.Name)
+	data.Project = types.StringValue(apiID(result.Project))
+	data.Target = types.StringValue(apiID(result.Target))
+	resp.Diagnostics.Append(r.setComputed(ctx, &data, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only refreshes the computed attributes: every argument forces replacement.
+func (r *deploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data deploymentModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result deploymentAPI
+	if err := doJSON(ctx, r.meta, http.MethodGet, deploymentURL(data.ID.ValueString()), nil, &result); err != nil {
+		resp.Diagnostics.AddError("Failed to read deployment", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(r.setComputed(ctx, &data, result)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *deploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data deploymentModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodDelete, deploymentURL(data.ID.ValueString()), nil, nil)
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Failed to delete deployment", err.Error())
+	}
+}
+
+func (r *deploymentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// setComputed copies the attributes computed by the API into data.
+func (r *deploymentResource) setComputed(ctx context.Context, data *deploymentModel, result deploymentAPI) diag.Diagnostics {
+	data.ID = types.StringValue(result.ID)
+	data.URL = types.StringValue(result.URL)
+
+	aliases := make([]string, 0, len(result.Aliases))
+	for _, a := range result.Aliases {
+		aliases = append(aliases, a.Name)
+	}
+	set, diags := types.SetValueFrom(ctx, types.StringType, aliases)
+	data.Aliases = set
+	return diags
+}
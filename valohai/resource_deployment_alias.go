@@ -0,0 +1,185 @@
+package valohai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type deploymentAliasResource struct {
+	frameworkMeta
+}
+
+var (
+	_ resource.ResourceWithConfigure   = &deploymentAliasResource{}
+	_ resource.ResourceWithImportState = &deploymentAliasResource{}
+)
+
+func newDeploymentAliasResource() resource.Resource {
+	return &deploymentAliasResource{}
+}
+
+// DeploymentAliasResource returns the valohai_deployment_alias resource.
+func DeploymentAliasResource() resource.Resource {
+	return newDeploymentAliasResource()
+}
+
+// deploymentAliasModel maps the valohai_deployment_alias schema.
+type deploymentAliasModel struct {
+	ID         types.String `tfsdk:"id"`
+	Deployment types.String `tfsdk:"deployment"`
+	Name       types.String `tfsdk:"name"`
+	Version    types.String `tfsdk:"version"`
+}
+
+// deploymentAliasAPI is a deployment version alias as returned by the API.
+type deploymentAliasAPI struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Deployment interface{} `json:"deployment"`
+	Target     interface{} `json:"target"`
+}
+
+func (r *deploymentAliasResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment_alias"
+}
+
+func (r *deploymentAliasResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Alias such as production pointing at a version of a Valohai deployment.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the alias.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deployment": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the deployment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the alias, part of the endpoint URLs.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the deployment version the alias points at. Changing it moves the alias once the new version is ready.",
+			},
+		},
+	}
+}
+
+func (r *deploymentAliasResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func deploymentAliasURL(id string) string {
+	return fmt.Sprintf("https://app.valohai.com/api/v0/deployment-version-aliases/%s/", id)
+}
+
+func (r *deploymentAliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data deploymentAliasModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Never point an alias at a version that is still rolling out or failed.
+	if _, err := waitForDeploymentVersion(ctx, r.meta, data.Version.ValueString(), deploymentRolloutTimeout); err != nil {
+		resp.Diagnostics.AddError("Deployment version is not ready", err.Error())
+		return
+	}
+
+	var result deploymentAliasAPI
+	err := doJSON(ctx, r.meta, http.MethodPost, "https://app.valohai.com/api/v0/deployment-version-aliases/", map[string]interface{}{
+		"deployment": data.Deployment.ValueString(),
+		"name":       data.Name.ValueString(),
+		"target":     data.Version.ValueString(),
+	}, &result)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create deployment alias", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(result.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *deploymentAliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data deploymentAliasModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result deploymentAliasAPI
+	err := doJSON(ctx, r.meta, http.MethodGet, deploymentAliasURL(data.ID.ValueString()), nil, &result)
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read deployment alias", err.Error())
+		return
+	}
+
+	data.Name = types.StringValue(result.Name)
+	data.Deployment = types.StringValue(apiID(result.Deployment))
+	data.Version = types.StringValue(apiID(result.Target))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *deploymentAliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data deploymentAliasModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := waitForDeploymentVersion(ctx, r.meta, data.Version.ValueString(), deploymentRolloutTimeout); err != nil {
+		resp.Diagnostics.AddError("Deployment version is not ready", err.Error())
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodPatch, deploymentAliasURL(data.ID.ValueString()), map[string]interface{}{
+		"target": data.Version.ValueString(),
+	}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to move deployment alias", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *deploymentAliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data deploymentAliasModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodDelete, deploymentAliasURL(data.ID.ValueString()), nil, nil)
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Failed to delete deployment alias", err.Error())
+	}
+}
+
+func (r *deploymentAliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
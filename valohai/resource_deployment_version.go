@@ -0,0 +1,448 @@
+package valohai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// deploymentRolloutTimeout bounds how long an apply waits for a deployment
+// version to roll out.
+const deploymentRolloutTimeout = 15 * time.Minute
+
+type deploymentVersionResource struct {
+	frameworkMeta
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &deploymentVersionResource{}
+	_ resource.ResourceWithValidateConfig = &deploymentVersionResource{}
+)
+
+func newDeploymentVersionResource() resource.Resource {
+	return &deploymentVersionResource{}
+}
+
+// DeploymentVersionResource returns the valohai_deployment_version resource.
+func DeploymentVersionResource() resource.Resource {
+	return newDeploymentVersionResource()
+}
+
+// deploymentVersionModel maps the valohai_deployment_version schema.
+type deploymentVersionModel struct {
+	ID         types.String              `tfsdk:"id"`
+	Deployment types.String              `tfsdk:"deployment"`
+	Commit     types.String              `tfsdk:"commit"`
+	Name       types.String              `tfsdk:"name"`
+	Enabled    types.Bool                `tfsdk:"enabled"`
+	Status     types.String              `tfsdk:"status"`
+	URL        types.String              `tfsdk:"url"`
+	Endpoints  []deploymentEndpointModel `tfsdk:"endpoint"`
+}
+
+type deploymentEndpointModel struct {
+	Name     types.String  `tfsdk:"name"`
+	Replicas types.Int64   `tfsdk:"replicas"`
+	CPU      types.Float64 `tfsdk:"cpu"`
+	Memory   types.Int64   `tfsdk:"memory"`
+}
+
+// deploymentVersionAPI is a deployment version as returned by the API.
+type deploymentVersionAPI struct {
+	ID            string      `json:"id"`
+	Name          string      `json:"name"`
+	Deployment    interface{} `json:"deployment"`
+	Commit        interface{} `json:"commit"`
+	Enabled       bool        `json:"enabled"`
+	Status        string      `json:"status"`
+	StatusMessage string      `json:"status_message"`
+	URL           string      `json:"url"`
+
+	EndpointConfigurations map[string]deploymentEndpointAPI `json:"endpoint_configurations"`
+}
+
+// deploymentEndpointAPI is the configuration of one endpoint of a version.
+type deploymentEndpointAPI struct {
+	Enabled   bool   `json:"enabled"`
+	Replicas  *int64 `json:"replicas"`
+	Resources struct {
+		CPU *struct {
+			Max float64 `json:"max"`
+		} `json:"cpu"`
+		Memory *struct {
+			Max int64 `json:"max"`
+		} `json:"memory"`
+	} `json:"resources"`
+}
+
+func (r *deploymentVersionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment_version"
+}
+
+func (r *deploymentVersionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Version of a Valohai deployment published from a project commit. Versions are immutable: changing the commit or the endpoints publishes a new version.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the deployment version.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deployment": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the deployment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"commit": schema.StringAttribute{
+				Required:    true,
+				Description: "Identifier of the project commit to publish.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the version. Generated by Valohai when not set.",
+				PlanModifiers: []planmodifier.String{
+					// Keep the generated name first: in-place changes would
+					// otherwise plan it unknown and force a replacement.
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the version serves requests. Defaults to true.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Rollout status of the version.",
+			},
+			"url": schema.StringAttribute{
+				Computed:    true,
+				Description: "API URL of the deployment version.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"endpoint": schema.ListNestedBlock{
+				Description: "Endpoint of the commit's valohai.yaml to serve. At least one is required.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "Name of the endpoint in valohai.yaml.",
+						},
+						"replicas": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Number of replicas serving the endpoint.",
+						},
+						"cpu": schema.Float64Attribute{
+							Optional:    true,
+							Description: "CPU limit of each replica, in cores.",
+						},
+						"memory": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Memory limit of each replica, in megabytes.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *deploymentVersionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *deploymentVersionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	known, diags := blocksKnown(ctx, req.Config.GetAttribute, "endpoint")
+	resp.Diagnostics.Append(diags...)
+	if !known {
+		return
+	}
+	var data deploymentVersionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(data.Endpoints) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("endpoint"), "Missing endpoint", "a deployment version must serve at least one endpoint")
+		return
+	}
+	for i, e := range data.Endpoints {
+		if !e.Replicas.IsNull() && !e.Replicas.IsUnknown() && e.Replicas.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoint").AtListIndex(i).AtName("replicas"), "Invalid replicas", "replicas must be at least 1")
+		}
+	}
+}
+
+func deploymentVersionURL(id string) string {
+	return fmt.Sprintf("https://app.valohai.com/api/v0/deployment-versions/%s/", id)
+}
+
+// endpointConfigurations builds the endpoint_configurations payload of a version.
+func endpointConfigurations(endpoints []deploymentEndpointModel) map[string]interface{} {
+	configs := make(map[string]interface{}, len(endpoints))
+	for _, e := range endpoints {
+		config := map[string]interface{}{"enabled": true}
+		if !e.Replicas.IsNull() {
+			config["replicas"] = e.Replicas.ValueInt64()
+		}
+		resources := map[string]interface{}{}
+		if !e.CPU.IsNull() {
+			resources["cpu"] = map[string]interface{}{"max": e.CPU.ValueFloat64()}
+		}
+		if !e.Memory.IsNull() {
+			resources["memory"] = map[string]interface{}{"max": e.Memory.ValueInt64()}
+		}
+		if len(resources) > 0 {
+			config["resources"] = resources
+		}
+		configs[e.Name.ValueString()] = config
+	}
+	return configs
+}
+
+func (r *deploymentVersionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data deploymentVersionModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := map[string]interface{}{
+		"deployment":              data.Deployment.ValueString(),
+		"commit":                  data.Commit.ValueString(),
+		"enabled":                 data.Enabled.ValueBool(),
+		"endpoint_configurations": endpointConfigurations(data.Endpoints),
+	}
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		body["name"] = data.Name.ValueString()
+	}
+
+	var result deploymentVersionAPI
+	if err := doJSON(ctx, r.meta, http.MethodPost, "https://app.valohai.com/api/v0/deployment-versions/", body, &result); err != nil {
+		resp.Diagnostics.AddError("Failed to create deployment version", err.Error())
+		return
+	}
+	data.setComputed(result)
+	// Save the version before waiting so a failed rollout leaves it tainted
+	// in state instead of orphaned.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !data.Enabled.ValueBool() {
+		return
+	}
+
+	result, err := waitForDeploymentVersion(ctx, r.meta, result.ID, deploymentRolloutTimeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Deployment version rollout failed", err.Error())
+		return
+	}
+	data.setComputed(result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *deploymentVersionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data deploymentVersionModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result deploymentVersionAPI
+	err := doJSON(ctx, r.meta, http.MethodGet, deploymentVersionURL(data.ID.ValueString()), nil, &result)
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read deployment version", err.Error())
+		return
+	}
+
+	data.Deployment = types.StringValue(apiID(result.Deployment))
+	if commit := commitIdentifier(result.Commit); commit != "" {
+		data.Commit = types.StringValue(commit)
+	}
+	if result.EndpointConfigurations != nil {
+		data.Endpoints = readEndpoints(data.Endpoints, result.EndpointConfigurations)
+	}
+	data.Enabled = types.BoolValue(result.Enabled)
+	data.setComputed(result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only toggles enabled: every other argument forces replacement.
+func (r *deploymentVersionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data deploymentVersionModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result deploymentVersionAPI
+	err := doJSON(ctx, r.meta, http.MethodPatch, deploymentVersionURL(data.ID.ValueString()), map[string]interface{}{
+		"enabled": data.Enabled.ValueBool(),
+	}, &result)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update deployment version", err.Error())
+		return
+	}
+	if data.Enabled.ValueBool() {
+		result, err = waitForDeploymentVersion(ctx, r.meta, result.ID, deploymentRolloutTimeout)
+		if err != nil {
+			resp.Diagnostics.AddError("Deployment version rollout failed", err.Error())
+			return
+		}
+	}
+	data.setComputed(result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *deploymentVersionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data deploymentVersionModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodDelete, deploymentVersionURL(data.ID.ValueString()), nil, nil)
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Failed to delete deployment version", err.Error())
+	}
+}
+
+// commitIdentifier returns the identifier of a commit given either as a
+// string or as a {"identifier": ...} object.
+func commitIdentifier(v interface{}) string {
+	if c, ok := v.(map[string]interface{}); ok {
+		if identifier, ok := c["identifier"].(string); ok {
+			return identifier
+		}
+	}
+	return apiID(v)
+}
+
+// readEndpoints refreshes the endpoint blocks in state from the enabled
+// endpoint configurations of a version, keeping the order of the blocks.
+// Limits left unset in state stay null rather than picking up the defaults
+// Valohai fills in; endpoints only known to the API are appended by name.
+func readEndpoints(state []deploymentEndpointModel, configs map[string]deploymentEndpointAPI) []deploymentEndpointModel {
+	endpoints := make([]deploymentEndpointModel, 0, len(configs))
+	seen := make(map[string]bool, len(configs))
+	for _, e := range state {
+		config, ok := configs[e.Name.ValueString()]
+		if !ok || !config.Enabled {
+			continue
+		}
+		seen[e.Name.ValueString()] = true
+		remote := endpointFromAPI(e.Name.ValueString(), config)
+		if !e.Replicas.IsNull() {
+			e.Replicas = remote.Replicas
+		}
+		if !e.CPU.IsNull() {
+			e.CPU = remote.CPU
+		}
+		if !e.Memory.IsNull() {
+			e.Memory = remote.Memory
+		}
+		endpoints = append(endpoints, e)
+	}
+
+	names := make([]string, 0, len(configs))
+	for name, config := range configs {
+		if config.Enabled && !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		endpoints = append(endpoints, endpointFromAPI(name, configs[name]))
+	}
+	return endpoints
+}
+
+// endpointFromAPI maps an endpoint configuration to its block.
+func endpointFromAPI(name string, config deploymentEndpointAPI) deploymentEndpointModel {
+	e := deploymentEndpointModel{
+		Name:     types.StringValue(name),
+		Replicas: types.Int64PointerValue(config.Replicas),
+		CPU:      types.Float64Null(),
+		Memory:   types.Int64Null(),
+	}
+	if config.Resources.CPU != nil {
+		e.CPU = types.Float64Value(config.Resources.CPU.Max)
+	}
+	if config.Resources.Memory != nil {
+		e.Memory = types.Int64Value(config.Resources.Memory.Max)
+	}
+	return e
+}
+
+// setComputed copies the attributes computed by the API into data.
+func (data *deploymentVersionModel) setComputed(result deploymentVersionAPI) {
+	data.ID = types.StringValue(result.ID)
+	data.Name = types.StringValue(result.Name)
+	data.Status = types.StringValue(result.Status)
+	data.URL = types.StringValue(result.URL)
+}
+
+// deploymentVersionTransitional lists the rollout statuses a version leaves on
+// its own. Every other status is final.
+var deploymentVersionTransitional = map[string]bool{
+	"":          true,
+	"created":   true,
+	"pending":   true,
+	"queued":    true,
+	"building":  true,
+	"deploying": true,
+	"starting":  true,
+	"updating":  true,
+}
+
+// waitForDeploymentVersion polls the version until its rollout finishes. A
+// version that ends in any final status other than ready, such as failed,
+// crashed, disabled or stopped, stops the polling with its status message.
+func waitForDeploymentVersion(ctx context.Context, m map[string]interface{}, id string, timeout time.Duration) (deploymentVersionAPI, error) {
+	var result deploymentVersionAPI
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		if err := doJSON(ctx, m, http.MethodGet, deploymentVersionURL(id), nil, &result); err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if result.Status == "ready" {
+			return nil
+		}
+		if !deploymentVersionTransitional[result.Status] {
+			return retry.NonRetryableError(fmt.Errorf("deployment version %s rollout ended with status %q: %s", id, result.Status, result.StatusMessage))
+		}
+		return retry.RetryableError(fmt.Errorf("deployment version %s is %s", id, result.Status))
+	})
+	return result, err
+}
@@ -0,0 +1,241 @@
+package valohai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type notificationRoutingResource struct {
+	frameworkMeta
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &notificationRoutingResource{}
+	_ resource.ResourceWithImportState    = &notificationRoutingResource{}
+	_ resource.ResourceWithValidateConfig = &notificationRoutingResource{}
+)
+
+func newNotificationRoutingResource() resource.Resource {
+	return &notificationRoutingResource{}
+}
+
+// NotificationRoutingResource returns the valohai_notification_routing resource.
+func NotificationRoutingResource() resource.Resource {
+	return newNotificationRoutingResource()
+}
+
+// notificationEvents are the event types a routing can subscribe to.
+var notificationEvents = []string{
+	"execution_completed",
+	"execution_failed",
+	"pipeline_completed",
+	"pipeline_failed",
+	"deployment_version_failed",
+}
+
+// notificationRoutingModel maps the valohai_notification_routing schema.
+type notificationRoutingModel struct {
+	ID           types.String `tfsdk:"id"`
+	Channel      types.String `tfsdk:"channel"`
+	Project      types.String `tfsdk:"project"`
+	Organization types.String `tfsdk:"organization"`
+	Events       types.Set    `tfsdk:"events"`
+}
+
+// notificationRoutingAPI is a routing as returned by the API.
+type notificationRoutingAPI struct {
+	ID           string      `json:"id"`
+	Channel      interface{} `json:"channel"`
+	Project      interface{} `json:"project"`
+	Organization interface{} `json:"organization"`
+	Events       []string    `json:"events"`
+}
+
+func (r *notificationRoutingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_routing"
+}
+
+func (r *notificationRoutingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sends the notifications of a project or an organization to a notification channel.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the routing.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"channel": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the notification channel receiving the events.",
+			},
+			"project": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the project whose events are routed. Exactly one of project or organization is required.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"organization": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the organization whose events, across all its projects, are routed. Exactly one of project or organization is required.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"events": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Event types to route: " + strings.Join(notificationEvents, ", ") + ".",
+			},
+		},
+	}
+}
+
+func (r *notificationRoutingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *notificationRoutingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data notificationRoutingModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Project.IsNull() == data.Organization.IsNull() && !data.Project.IsUnknown() && !data.Organization.IsUnknown() {
+		resp.Diagnostics.AddError("Invalid routing scope", "exactly one of project or organization is required")
+	}
+	if data.Events.IsUnknown() {
+		return
+	}
+	var events []types.String
+	resp.Diagnostics.Append(data.Events.ElementsAs(ctx, &events, false)...)
+	if len(events) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("events"), "Missing events", "at least one event type is required")
+	}
+	for _, e := range events {
+		if e.IsUnknown() {
+			continue
+		}
+		valid := false
+		for _, known := range notificationEvents {
+			valid = valid || e.ValueString() == known
+		}
+		if !valid {
+			resp.Diagnostics.AddAttributeError(path.Root("events"), "Invalid event type",
+				fmt.Sprintf("expected one of %s, got %q", strings.Join(notificationEvents, ", "), e.ValueString()))
+		}
+	}
+}
+
+func notificationRoutingURL(id string) string {
+	return fmt.Sprintf("https://app.valohai.com/api/v0/notification-routings/%s/", id)
+}
+
+func (r *notificationRoutingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data notificationRoutingModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var events []string
+	resp.Diagnostics.Append(data.Events.ElementsAs(ctx, &events, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	body := map[string]interface{}{
+		"channel": data.Channel.ValueString(),
+		"events":  events,
+	}
+	setOptionalString(body, "project", data.Project)
+	setOptionalString(body, "organization", data.Organization)
+
+	var result notificationRoutingAPI
+	if err := doJSON(ctx, r.meta, http.MethodPost, "https://app.valohai.com/api/v0/notification-routings/", body, &result); err != nil {
+		resp.Diagnostics.AddError("Failed to create notification routing", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(result.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *notificationRoutingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data notificationRoutingModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result notificationRoutingAPI
+	err := doJSON(ctx, r.meta, http.MethodGet, notificationRoutingURL(data.ID.ValueString()), nil, &result)
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read notification routing", err.Error())
+		return
+	}
+
+	data.Channel = types.StringValue(apiID(result.Channel))
+	data.Project = optionalString(apiID(result.Project))
+	data.Organization = optionalString(apiID(result.Organization))
+	events, diags := types.SetValueFrom(ctx, types.StringType, result.Events)
+	resp.Diagnostics.Append(diags...)
+	data.Events = events
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *notificationRoutingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data notificationRoutingModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var events []string
+	resp.Diagnostics.Append(data.Events.ElementsAs(ctx, &events, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := doJSON(ctx, r.meta, http.MethodPatch, notificationRoutingURL(data.ID.ValueString()), map[string]interface{}{
+		"channel": data.Channel.ValueString(),
+		"events":  events,
+	}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update notification routing", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *notificationRoutingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data notificationRoutingModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodDelete, notificationRoutingURL(data.ID.ValueString()), nil, nil)
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Failed to delete notification routing", err.Error())
+	}
+}
+
+func (r *notificationRoutingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,214 @@
+package valohai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type projectStoreBindingResource struct {
+	frameworkMeta
+}
+
+var (
+	_ resource.ResourceWithConfigure   = &projectStoreBindingResource{}
+	_ resource.ResourceWithImportState = &projectStoreBindingResource{}
+)
+
+func newProjectStoreBindingResource() resource.Resource {
+	return &projectStoreBindingResource{}
+}
+
+// ProjectStoreBindingResource returns the valohai_project_store_binding resource.
+func ProjectStoreBindingResource() resource.Resource {
+	return newProjectStoreBindingResource()
+}
+
+// projectStoreBindingModel maps the valohai_project_store_binding schema.
+type projectStoreBindingModel struct {
+	ID         types.String `tfsdk:"id"`
+	Project    types.String `tfsdk:"project"`
+	Store      types.String `tfsdk:"store"`
+	AllowRead  types.Bool   `tfsdk:"allow_read"`
+	AllowWrite types.Bool   `tfsdk:"allow_write"`
+}
+
+// projectStoreBindingAPI is the binding as sent to and returned by the API.
+type projectStoreBindingAPI struct {
+	Store      string `json:"store"`
+	AllowRead  bool   `json:"allow_read"`
+	AllowWrite bool   `json:"allow_write"`
+}
+
+func (r *projectStoreBindingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_store_binding"
+}
+
+func (r *projectStoreBindingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Makes a Valohai store available to a project.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Binding identifier in the form <project>/<store>.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"store": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the store.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"allow_read": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether executions of the project may read from the store. Defaults to true.",
+			},
+			"allow_write": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether executions of the project may write outputs to the store. Defaults to true.",
+			},
+		},
+	}
+}
+
+func (r *projectStoreBindingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+func projectStoresURL(project string) string {
+	return fmt.Sprintf("https://app.valohai.com/api/v0/projects/%s/stores/", project)
+}
+
+func projectStoreURL(project, store string) string {
+	return fmt.Sprintf("https://app.valohai.com/api/v0/projects/%s/stores/%s/", project, store)
+}
+
+func (r *projectStoreBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data projectStoreBindingModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodPost, projectStoresURL(data.Project.ValueString()), projectStoreBindingAPI{
+		Store:      data.Store.ValueString(),
+		AllowRead:  data.AllowRead.ValueBool(),
+		AllowWrite: data.AllowWrite.ValueBool(),
+	}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to bind store to project", err.Error())
+		return
+	}
+
+	// Read the binding back rather than trusting the POST response for the flags
+	result, err := r.readBinding(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read project store binding", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(data.Project.ValueString() + "/" + data.Store.ValueString())
+	data.AllowRead = types.BoolValue(result.AllowRead)
+	data.AllowWrite = types.BoolValue(result.AllowWrite)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectStoreBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data projectStoreBindingModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.readBinding(ctx, data)
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read project store binding", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(data.Project.ValueString() + "/" + data.Store.ValueString())
+	data.AllowRead = types.BoolValue(result.AllowRead)
+	data.AllowWrite = types.BoolValue(result.AllowWrite)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectStoreBindingResource) readBinding(ctx context.Context, data projectStoreBindingModel) (projectStoreBindingAPI, error) {
+	var result projectStoreBindingAPI
+	err := doJSON(ctx, r.meta, http.MethodGet, projectStoreURL(data.Project.ValueString(), data.Store.ValueString()), nil, &result)
+	return result, err
+}
+
+func (r *projectStoreBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data projectStoreBindingModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result projectStoreBindingAPI
+	err := doJSON(ctx, r.meta, http.MethodPatch, projectStoreURL(data.Project.ValueString(), data.Store.ValueString()), map[string]interface{}{
+		"allow_read":  data.AllowRead.ValueBool(),
+		"allow_write": data.AllowWrite.ValueBool(),
+	}, &result)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update project store binding", err.Error())
+		return
+	}
+
+	data.AllowRead = types.BoolValue(result.AllowRead)
+	data.AllowWrite = types.BoolValue(result.AllowWrite)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectStoreBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data projectStoreBindingModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodDelete, projectStoreURL(data.Project.ValueString(), data.Store.ValueString()), nil, nil)
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Failed to unbind store from project", err.Error())
+	}
+}
+
+// ImportState accepts an identifier in the form <project>/<store>.
+func (r *projectStoreBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, store, ok := strings.Cut(req.ID, "/")
+	if !ok || project == "" || store == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("expected <project>/<store>, got %q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), project)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("store"), store)...)
+}
@@ -2,22 +2,50 @@ package valohai
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceProject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProjectCreate,
-		Read:   resourceProjectRead,
-		Update: resourceProjectUpdate,
-		Delete: resourceProjectDelete,
+		CreateContext: contextCRUD(resourceProjectCreate),
+		ReadContext:   contextCRUD(resourceProjectRead),
+		UpdateContext: contextCRUD(resourceProjectUpdate),
+		DeleteContext: contextCRUD(resourceProjectDelete),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+		},
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceProjectImport,
+		},
+
+		CustomizeDiff: customdiff.All(
+			computedOnUpdate("mtime"),
+			validateProjectDefaultEnvironment,
+		),
+
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceProjectV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceProjectStateUpgradeV0,
+			},
+			{
+				Version: 1,
+				Type:    resourceProjectV1().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceProjectStateUpgradeV1,
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -38,8 +66,81 @@ func resourceProject() *schema.Resource {
 				Optional: true,
 			},
 			"default_notifications": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeBool,
 				Optional: true,
+				Computed: true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ctime": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mtime": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Prevent Terraform from deleting the project while true",
+			},
+			"default_environment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Slug of the environment executions run in when none is chosen",
+			},
+			"default_image": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Docker image executions use when the step does not define one",
+			},
+			"execution_time_limit_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Maximum duration of an execution in seconds, 0 for no limit",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"no_debug": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Disable debugging (SSH, debug mode) of the project executions",
+			},
+			"upload_store_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the store the project uploads execution outputs to",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tags used to group the project; replaces the tags of the project, removing all of them when empty or omitted",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"color": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Take over an existing project with the same name and owner instead of failing on create",
 			},
 		},
 	}
@@ -50,7 +151,7 @@ func ResourceProject() *schema.Resource {
 	return resourceProject()
 }
 
-func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	apiURL := "https://app.valohai.com/api/v0/projects/"
 	authToken := m.(map[string]interface{})["token"].(string)
 
@@ -66,8 +167,29 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 	if v, ok := d.GetOk("template_url"); ok {
 		payload["template"] = v.(string)
 	}
-	if v, ok := d.GetOk("default_notifications"); ok {
-		payload["default_notifications"] = v.(string) == "true"
+	if v, ok := d.GetOk("tags"); ok {
+		payload["tags"] = expandProjectTags(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("upload_store_id"); ok {
+		payload["upload_store_id"] = v.(string)
+	}
+	if v, ok := d.GetOk("default_environment"); ok {
+		payload["default_environment"] = v.(string)
+	}
+	if v, ok := d.GetOk("default_image"); ok {
+		payload["default_image"] = v.(string)
+	}
+	// Use the raw config so an explicit false or 0 is sent too
+	if raw := d.GetRawConfig(); !raw.IsNull() {
+		if v := raw.GetAttr("default_notifications"); !v.IsNull() {
+			payload["default_notifications"] = v.True()
+		}
+		if v := raw.GetAttr("no_debug"); !v.IsNull() {
+			payload["no_debug"] = v.True()
+		}
+		if v := raw.GetAttr("execution_time_limit_seconds"); !v.IsNull() {
+			payload["execution_time_limit_seconds"] = d.Get("execution_time_limit_seconds").(int)
+		}
 	}
 
 	// JSON encoding
@@ -77,7 +199,7 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -85,7 +207,7 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 	req.Header.Set("Authorization", "Token "+authToken)
 
 	// Send request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClientFromMeta(m).Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -93,6 +215,9 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Check HTTP status code
 	if resp.StatusCode != http.StatusCreated {
+		if adopted, err := adoptExistingProject(ctx, d, m, resp); adopted || err != nil {
+			return err
+		}
 		var errResp map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&errResp)
 		// Extraction du message et du code d'erreur s'ils existent
@@ -110,28 +235,37 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 
 	// Decode response
 	var result struct {
-		ID string `json:"id"`
+		ID    string `json:"id"`
+		URL   string `json:"url"`
+		Ctime string `json:"ctime"`
+		Mtime string `json:"mtime"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	d.SetId(result.ID) // Stocke l'UUID Valohai comme ID de la ressource
+	if err := waitUntilReadable(ctx, m, fmt.Sprintf("https://app.valohai.com/api/v0/projects/%s/", result.ID), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("project %s was created but is not readable yet: %w", result.ID, err)
+	}
+	d.Set("url", result.URL)
+	d.Set("ctime", result.Ctime)
+	d.Set("mtime", result.Mtime)
 
 	return nil
 }
 
-func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	authToken := m.(map[string]interface{})["token"].(string)
 	id := d.Id() // UUID du projet Valohai
 	url := fmt.Sprintf("https://app.valohai.com/api/v0/projects/%s/", id)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create GET request: %w", err)
 	}
 	req.Header.Set("Authorization", "Token "+authToken)
-	client := &http.Client{}
+	client := httpClientFromMeta(m)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute GET request: %w", err)
@@ -158,18 +292,35 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 
 	// Decode response
 	var result struct {
-		ID                   string      `json:"id"`
-		Name                 string      `json:"name"`
-		Owner                interface{} `json:"owner"`
-		Description          string      `json:"description"`
-		Template             string      `json:"template"`
-		DefaultNotifications bool        `json:"default_notifications"`
+		ID                   string       `json:"id"`
+		Name                 string       `json:"name"`
+		Owner                interface{}  `json:"owner"`
+		Description          string       `json:"description"`
+		Template             string       `json:"template"`
+		DefaultNotifications bool         `json:"default_notifications"`
+		URL                  string       `json:"url"`
+		Ctime                string       `json:"ctime"`
+		Mtime                string       `json:"mtime"`
+		Tags                 []projectTag `json:"tags"`
+		UploadStoreID        string       `json:"upload_store_id"`
+		DefaultEnvironment   string       `json:"default_environment"`
+		DefaultImage         string       `json:"default_image"`
+		ExecutionTimeLimit   int          `json:"execution_time_limit_seconds"`
+		NoDebug              bool         `json:"no_debug"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 	d.SetId(result.ID)
 	d.Set("name", result.Name)
+	if err := d.Set("tags", flattenProjectTags(result.Tags)); err != nil {
+		return fmt.Errorf("failed to set tags: %w", err)
+	}
+	d.Set("upload_store_id", result.UploadStoreID)
+	d.Set("default_environment", result.DefaultEnvironment)
+	d.Set("default_image", result.DefaultImage)
+	d.Set("execution_time_limit_seconds", result.ExecutionTimeLimit)
+	d.Set("no_debug", result.NoDebug)
 	// Gestion owner string ou map
 	if ownerStr, ok := result.Owner.(string); ok {
 		d.Set("owner", ownerStr)
@@ -180,14 +331,17 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 	}
 	d.Set("description", result.Description)
 	d.Set("template_url", result.Template)
-	// Ne set que si true, sinon laisse null
-	if result.DefaultNotifications {
-		d.Set("default_notifications", "true")
-	}
+	d.Set("default_notifications", result.DefaultNotifications)
+	d.Set("url", result.URL)
+	d.Set("ctime", result.Ctime)
+	d.Set("mtime", result.Mtime)
 	return nil
 }
 
-func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if !d.HasChangesExcept(localOnlyAttributes...) {
+		return nil
+	}
 	id := d.Id() // UUID du projet Valohai
 	apiURL := fmt.Sprintf("https://app.valohai.com/api/v0/projects/%s/", id)
 	authToken := m.(map[string]interface{})["token"].(string)
@@ -199,6 +353,37 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 	if v, ok := d.GetOk("description"); ok {
 		payload["description"] = v.(string)
 	}
+	if d.HasChange("default_notifications") {
+		payload["default_notifications"] = d.Get("default_notifications").(bool)
+	}
+	if d.HasChange("tags") {
+		payload["tags"] = expandProjectTags(d.Get("tags").(*schema.Set))
+	}
+	for _, k := range []string{"upload_store_id", "default_environment", "default_image"} {
+		if d.HasChange(k) {
+			payload[k] = d.Get(k).(string)
+		}
+	}
+	if d.HasChange("execution_time_limit_seconds") {
+		payload["execution_time_limit_seconds"] = d.Get("execution_time_limit_seconds").(int)
+	}
+	if d.HasChange("no_debug") {
+		payload["no_debug"] = d.Get("no_debug").(bool)
+	}
+
+	etag, err := checkNotModifiedSinceRead(ctx, d, m, apiURL, "project", map[string]string{
+		"name":                         "name",
+		"description":                  "description",
+		"default_notifications":        "default_notifications",
+		"upload_store_id":              "upload_store_id",
+		"default_environment":          "default_environment",
+		"default_image":                "default_image",
+		"execution_time_limit_seconds": "execution_time_limit_seconds",
+		"no_debug":                     "no_debug",
+	})
+	if err != nil {
+		return err
+	}
 
 	// JSON encoding
 	body, err := json.Marshal(payload)
@@ -207,21 +392,27 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequest("PUT", apiURL, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "PUT", apiURL, bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Token "+authToken)
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
 
 	// Send request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClientFromMeta(m).Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check HTTP status code
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return errPreconditionFailed("project", id)
+	}
 	if resp.StatusCode != http.StatusOK {
 		var errResp map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&errResp)
@@ -240,27 +431,32 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 
 	// Decode response
 	var result struct {
-		ID string `json:"id"`
+		ID    string `json:"id"`
+		Mtime string `json:"mtime"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	d.SetId(result.ID) // Stocke l'UUID Valohai comme ID de la ressource
+	d.Set("mtime", result.Mtime)
 	return nil
 }
 
-func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := deletionProtected(d, "project"); err != nil {
+		return err
+	}
 	authToken := m.(map[string]interface{})["token"].(string)
 	id := d.Id() // UUID du projet Valohai
 	url := fmt.Sprintf("https://app.valohai.com/api/v0/projects/%s/", id)
 
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create DELETE request: %w", err)
 	}
 	req.Header.Set("Authorization", "Token "+authToken)
-	client := &http.Client{}
+	client := httpClientFromMeta(m)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute DELETE request: %w", err)
@@ -272,6 +468,69 @@ func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// validateProjectDefaultEnvironment fails the plan when default_environment is
+// not one of the environments returned by the environments listing.
+func validateProjectDefaultEnvironment(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	m, ok := meta.(map[string]interface{})
+	if !ok || !d.HasChange("default_environment") || !d.NewValueKnown("default_environment") {
+		return nil
+	}
+	slug := d.Get("default_environment").(string)
+	if slug == "" {
+		return nil
+	}
+
+	var slugs []string
+	found := false
+	err := listEnvironments(ctx, m, ListOptions{}, func(e environmentAPI) (bool, error) {
+		if e.Slug == slug {
+			found = true
+			return true, nil
+		}
+		slugs = append(slugs, e.Slug)
+		return false, nil
+	})
+	if err != nil {
+		// The API checks the environment again on apply, so do not block the plan
+		tflog.Warn(ctx, "Skipping default_environment validation, failed to list environments", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+	if !found {
+		return fmt.Errorf("default_environment %q is not an environment available to this token; use the valohai_environments data source to list them (found: %s)", slug, strings.Join(slugs, ", "))
+	}
+	return nil
+}
+
+// projectTag is a tag as returned by the project API.
+type projectTag struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func expandProjectTags(set *schema.Set) []projectTag {
+	tags := make([]projectTag, 0, set.Len())
+	for _, raw := range set.List() {
+		t := raw.(map[string]interface{})
+		tags = append(tags, projectTag{Name: t["name"].(string), Color: t["color"].(string)})
+	}
+	return tags
+}
+
+func flattenProjectTags(tags []projectTag) []interface{} {
+	out := make([]interface{}, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, map[string]interface{}{"name": t.Name, "color": t.Color})
+	}
+	return out
+}
+
+// resourceProjectImport sets deletion_protection to its default, so imported
+// projects are protected and do not show a diff on the next plan.
+func resourceProjectImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("deletion_protection", true)
+	return []*schema.ResourceData{d}, nil
+}
+
 // GetOptionalString returns the string value for a key if set, otherwise an empty string.
 func GetOptionalString(d *schema.ResourceData, key string) string {
 	if v, ok := d.GetOk(key); ok {
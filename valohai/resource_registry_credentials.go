@@ -15,10 +15,14 @@ import (
 
 func resourceRegistryCredentials() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRegistryCredentialsCreate,
-		Read:   resourceRegistryCredentialsRead,
-		Update: resourceRegistryCredentialsUpdate,
-		Delete: resourceRegistryCredentialsDelete,
+		CreateContext: contextCRUD(resourceRegistryCredentialsCreate),
+		ReadContext:   contextCRUD(resourceRegistryCredentialsRead),
+		UpdateContext: contextCRUD(resourceRegistryCredentialsUpdate),
+		DeleteContext: contextCRUD(resourceRegistryCredentialsDelete),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+		},
 
 		CustomizeDiff: validateRegistryCredentialsConfiguration(),
 
@@ -161,7 +165,7 @@ func keysOfSet(m map[string]struct{}) []string {
 	return out
 }
 
-func resourceRegistryCredentialsCreate(d *schema.ResourceData, m interface{}) error {
+func resourceRegistryCredentialsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	apiURL := "https://app.valohai.com/api/v0/registry-credentials/"
 	authToken := m.(map[string]interface{})["token"].(string)
 
@@ -188,14 +192,14 @@ func resourceRegistryCredentialsCreate(d *schema.ResourceData, m interface{}) er
 		return fmt.Errorf("failed to encode payload: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Token "+authToken)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClientFromMeta(m).Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -213,21 +217,24 @@ func resourceRegistryCredentialsCreate(d *schema.ResourceData, m interface{}) er
 	}
 
 	d.SetId(result.ID)
-	return resourceRegistryCredentialsRead(d, m)
+	if err := waitUntilReadable(ctx, m, fmt.Sprintf("https://app.valohai.com/api/v0/registry-credentials/%s/", result.ID), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("registry credentials %s was created but is not readable yet: %w", result.ID, err)
+	}
+	return resourceRegistryCredentialsRead(ctx, d, m)
 }
 
-func resourceRegistryCredentialsRead(d *schema.ResourceData, m interface{}) error {
+func resourceRegistryCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	authToken := m.(map[string]interface{})["token"].(string)
 	id := d.Id()
 	url := fmt.Sprintf("https://app.valohai.com/api/v0/registry-credentials/%s/", id)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create GET request: %w", err)
 	}
 	req.Header.Set("Authorization", "Token "+authToken)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClientFromMeta(m).Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute GET request: %w", err)
 	}
@@ -254,7 +261,7 @@ func resourceRegistryCredentialsRead(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceRegistryCredentialsUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceRegistryCredentialsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	authToken := m.(map[string]interface{})["token"].(string)
 	apiURL := fmt.Sprintf("https://app.valohai.com/api/v0/registry-credentials/%s/", d.Id())
 
@@ -281,14 +288,14 @@ func resourceRegistryCredentialsUpdate(d *schema.ResourceData, m interface{}) er
 		return fmt.Errorf("failed to encode payload: %w", err)
 	}
 
-	req, err := http.NewRequest("PUT", apiURL, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "PUT", apiURL, bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Token "+authToken)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClientFromMeta(m).Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -298,21 +305,21 @@ func resourceRegistryCredentialsUpdate(d *schema.ResourceData, m interface{}) er
 		return formatAPIError(resp)
 	}
 
-	return resourceRegistryCredentialsRead(d, m)
+	return resourceRegistryCredentialsRead(ctx, d, m)
 }
 
-func resourceRegistryCredentialsDelete(d *schema.ResourceData, m interface{}) error {
+func resourceRegistryCredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	authToken := m.(map[string]interface{})["token"].(string)
 	id := d.Id()
 	url := fmt.Sprintf("https://app.valohai.com/api/v0/registry-credentials/%s/", id)
 
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create DELETE request: %w", err)
 	}
 	req.Header.Set("Authorization", "Token "+authToken)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClientFromMeta(m).Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute DELETE request: %w", err)
 	}
@@ -2,24 +2,45 @@ package valohai
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceStore() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceStoreCreate,
-		Read:   resourceStoreRead,
-		Update: resourceStoreUpdate,
-		Delete: resourceStoreDelete,
+		CreateContext: contextCRUD(resourceStoreCreate),
+		ReadContext:   contextCRUD(resourceStoreRead),
+		UpdateContext: contextCRUD(resourceStoreUpdate),
+		DeleteContext: contextCRUD(resourceStoreDelete),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+		},
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		// planStoreRestore goes first so that a restore-only plan also marks
+		// mtime unknown.
+		CustomizeDiff: customdiff.All(planStoreRestore, computedOnUpdate("mtime")),
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceStoreV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceStoreStateUpgradeV0,
+			},
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -106,6 +127,43 @@ func resourceStore() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"ctime": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation time of the store",
+			},
+			"mtime": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last modification time of the store",
+			},
+			"uri_prefix": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URI prefix of the files in the store, e.g. s3://bucket/",
+			},
+			"allow_adopt": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether existing files in the bucket can be adopted as datums",
+			},
+			"on_soft_deleted": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "What to do when the store has been deleted outside Terraform: recreate (default), restore or error",
+				ValidateFunc: validation.StringInSlice([]string{"recreate", "restore", "error"}, false),
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Prevent Terraform from deleting the store while true",
+			},
+			"soft_deleted": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the store has been deleted outside Terraform and is waiting to be restored (on_soft_deleted = \"restore\")",
+			},
 		},
 	}
 }
@@ -114,7 +172,7 @@ func ResourceStore() *schema.Resource {
 	return resourceStore()
 }
 
-func resourceStoreCreate(d *schema.ResourceData, m interface{}) error {
+func resourceStoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	accessMode := ""
 	if v, ok := d.GetOk("access_mode"); ok {
 		accessMode = v.(string)
@@ -250,7 +308,7 @@ func resourceStoreCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -258,7 +316,7 @@ func resourceStoreCreate(d *schema.ResourceData, m interface{}) error {
 	req.Header.Set("Authorization", "Token "+authToken)
 
 	// Send request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClientFromMeta(m).Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -297,11 +355,18 @@ func resourceStoreCreate(d *schema.ResourceData, m interface{}) error {
 		Teams            []string               `json:"teams"`
 		Project          interface{}            `json:"project"`
 		URL              string                 `json:"url"`
+		Ctime            string                 `json:"ctime"`
+		Mtime            string                 `json:"mtime"`
+		UriPrefix        interface{}            `json:"uri_prefix"`
+		AllowAdopt       bool                   `json:"allow_adopt"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 	d.SetId(result.ID)
+	if err := waitUntilReadable(ctx, m, fmt.Sprintf("https://app.valohai.com/api/v0/stores/%s/", result.ID), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("store %s was created but is not readable yet: %w", result.ID, err)
+	}
 	d.Set("name", result.Name)
 	d.Set("type", result.Type)
 	d.Set("access_mode", result.AccessMode)
@@ -357,20 +422,21 @@ func resourceStoreCreate(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 	d.Set("url", result.URL)
+	setStoreComputed(d, result.Ctime, result.Mtime, result.UriPrefix, result.AllowAdopt)
 	return nil
 }
 
-func resourceStoreRead(d *schema.ResourceData, m interface{}) error {
+func resourceStoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	authToken := m.(map[string]interface{})["token"].(string)
 	id := d.Id() // UUID store Valohai
 	url := fmt.Sprintf("https://app.valohai.com/api/v0/stores/%s/", id)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create GET request: %w", err)
 	}
 	req.Header.Set("Authorization", "Token "+authToken)
-	client := &http.Client{}
+	client := httpClientFromMeta(m)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute GET request: %w", err)
@@ -414,11 +480,30 @@ func resourceStoreRead(d *schema.ResourceData, m interface{}) error {
 		AllowAdopt       bool                   `json:"allow_adopt"`
 		AccessMode       string                 `json:"access_mode"`
 		AllowURIDownload bool                   `json:"allow_uri_download"`
+		URL              string                 `json:"url"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
+
+	// Stores deleted in the UI are only flagged as deleted by the API
+	if result.Deleted {
+		switch d.Get("on_soft_deleted").(string) {
+		case "error":
+			return fmt.Errorf("store %s has been deleted outside Terraform; restore it in Valohai or set on_soft_deleted to \"recreate\" or \"restore\"", id)
+		case "restore":
+			// Refresh must not change remote objects: only record the state
+			// here, planStoreRestore plans the restore for the next apply.
+			tflog.Warn(ctx, "Store has been deleted outside Terraform, it will be restored on the next apply", map[string]interface{}{"id": id})
+		default:
+			tflog.Warn(ctx, "Store has been deleted outside Terraform, removing it from state", map[string]interface{}{"id": id})
+			d.SetId("")
+			return nil
+		}
+	}
+
 	d.SetId(result.ID)
+	d.Set("soft_deleted", result.Deleted)
 	d.Set("type", result.Type)
 	d.Set("name", result.Name)
 	d.Set("owner_id", result.Owner)
@@ -470,15 +555,33 @@ func resourceStoreRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("allow_write", result.AllowWrite)
 	d.Set("access_mode", result.AccessMode)
 	d.Set("allow_uri_download", result.AllowURIDownload)
+	if result.URL != "" {
+		d.Set("url", result.URL)
+	}
+	setStoreComputed(d, result.Ctime, result.Mtime, result.UriPrefix, result.AllowAdopt)
 
 	return nil
 }
 
-func resourceStoreUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceStoreUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	// Restore only when planStoreRestore planned it
+	wasDeleted, deleted := d.GetChange("soft_deleted")
+	restore := wasDeleted.(bool) && !deleted.(bool)
+	if !restore && !d.HasChangesExcept(localOnlyAttributes...) {
+		return nil
+	}
 	id := d.Id() // UUID store Valohai
 	apiURL := fmt.Sprintf("https://app.valohai.com/api/v0/stores/%s/", id)
 	authToken := m.(map[string]interface{})["token"].(string)
 
+	if restore && !d.HasChangesExcept(append([]string{"soft_deleted"}, localOnlyAttributes...)...) {
+		if _, err := restoreStore(ctx, m, id); err != nil {
+			return err
+		}
+		tflog.Info(ctx, "Restored soft-deleted store", map[string]interface{}{"id": id})
+		return resourceStoreRead(ctx, d, m)
+	}
+
 	payload := map[string]interface{}{}
 
 	if v, ok := d.GetOk("name"); ok {
@@ -578,6 +681,24 @@ func resourceStoreUpdate(d *schema.ResourceData, m interface{}) error {
 		payload["teams"] = teams
 	}
 
+	etag, err := checkNotModifiedSinceRead(ctx, d, m, apiURL, "store", map[string]string{
+		"name":               "name",
+		"access_mode":        "access_mode",
+		"allow_read":         "allow_read",
+		"allow_write":        "allow_write",
+		"allow_uri_download": "allow_uri_download",
+	})
+	if err != nil {
+		return err
+	}
+	// Restore only after the check: the restore itself bumps mtime and the ETag
+	if restore {
+		if etag, err = restoreStore(ctx, m, id); err != nil {
+			return err
+		}
+		tflog.Info(ctx, "Restored soft-deleted store", map[string]interface{}{"id": id})
+	}
+
 	// JSON encoding
 	body, err := json.Marshal(payload)
 	if err != nil {
@@ -585,21 +706,27 @@ func resourceStoreUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequest("PUT", apiURL, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "PUT", apiURL, bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Token "+authToken)
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
 
 	// Send request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClientFromMeta(m).Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check HTTP status code
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return errPreconditionFailed("store", id)
+	}
 	if resp.StatusCode != http.StatusOK {
 		var errResp map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&errResp)
@@ -628,6 +755,10 @@ func resourceStoreUpdate(d *schema.ResourceData, m interface{}) error {
 		Owner            int                    `json:"owner"`
 		Paths            map[string]interface{} `json:"paths"`
 		Teams            []string               `json:"teams"`
+		Ctime            string                 `json:"ctime"`
+		Mtime            string                 `json:"mtime"`
+		UriPrefix        interface{}            `json:"uri_prefix"`
+		AllowAdopt       bool                   `json:"allow_adopt"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return fmt.Errorf("failed to decode update response: %w", err)
@@ -671,20 +802,71 @@ func resourceStoreUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 	d.Set("paths", paths)
 	d.Set("teams", result.Teams)
+	setStoreComputed(d, result.Ctime, result.Mtime, result.UriPrefix, result.AllowAdopt)
 	return nil
 }
 
-func resourceStoreDelete(d *schema.ResourceData, m interface{}) error {
+// setStoreComputed stores the read-only attributes returned by the store API.
+func setStoreComputed(d *schema.ResourceData, ctime, mtime string, uriPrefix interface{}, allowAdopt bool) {
+	d.Set("ctime", ctime)
+	d.Set("mtime", mtime)
+	// uri_prefix is null until the store has been validated
+	if s, ok := uriPrefix.(string); ok {
+		d.Set("uri_prefix", s)
+	} else {
+		d.Set("uri_prefix", "")
+	}
+	d.Set("allow_adopt", allowAdopt)
+}
+
+// planStoreRestore plans an in-place update restoring a store that Read found
+// soft-deleted while on_soft_deleted is "restore".
+func planStoreRestore(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("soft_deleted").(bool) || d.Get("on_soft_deleted").(string) != "restore" {
+		return nil
+	}
+	return d.SetNew("soft_deleted", false)
+}
+
+// restoreStore clears the deleted flag of a soft-deleted store and returns
+// the ETag of the restored store, if the API sends one.
+func restoreStore(ctx context.Context, m interface{}, id string) (string, error) {
+	authToken := m.(map[string]interface{})["token"].(string)
+	url := fmt.Sprintf("https://app.valohai.com/api/v0/stores/%s/", id)
+
+	body, _ := json.Marshal(map[string]interface{}{"deleted": false})
+	req, err := http.NewRequestWithContext(ctx, "PATCH", url, bytes.NewBuffer(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create PATCH request: %w", err)
+	}
+	req.Header.Set("Authorization", "Token "+authToken)
+	req.Header.Set("Content-Type", "application/json")
+	client := httpClientFromMeta(m)
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute PATCH request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to restore store %s: %w", id, parseAPIError(resp))
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+func resourceStoreDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := deletionProtected(d, "store"); err != nil {
+		return err
+	}
 	authToken := m.(map[string]interface{})["token"].(string)
 	id := d.Id() // UUID Store Valohai
 	url := fmt.Sprintf("https://app.valohai.com/api/v0/stores/%s/", id)
 
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create DELETE request: %w", err)
 	}
 	req.Header.Set("Authorization", "Token "+authToken)
-	client := &http.Client{}
+	client := httpClientFromMeta(m)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute DELETE request: %w", err)
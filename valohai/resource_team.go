@@ -2,6 +2,7 @@ package valohai
 
 import (
     "bytes"
+    "context"
     "encoding/json"
     "fmt"
     "io"
@@ -13,10 +14,14 @@ import (
 
 func resourceTeam() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTeamCreate,
-		Read:   resourceTeamRead,
-		Update: resourceTeamUpdate,
-		Delete: resourceTeamDelete,
+		CreateContext: contextCRUD(resourceTeamCreate),
+		ReadContext:   contextCRUD(resourceTeamRead),
+		UpdateContext: contextCRUD(resourceTeamUpdate),
+		DeleteContext: contextCRUD(resourceTeamDelete),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+		},
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -35,6 +40,11 @@ func resourceTeam() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Take over an existing team with the same name and organization instead of failing on create",
+			},
 		},
 	}
 }
@@ -44,7 +54,7 @@ func ResourceTeam() *schema.Resource {
 	return resourceTeam()
 }
 
-func resourceTeamCreate(d *schema.ResourceData, m interface{}) error {
+func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	apiURL := "https://app.valohai.com/api/v0/teams/"
 	authToken := m.(map[string]interface{})["token"].(string)
 
@@ -61,7 +71,7 @@ func resourceTeamCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -69,7 +79,7 @@ func resourceTeamCreate(d *schema.ResourceData, m interface{}) error {
 	req.Header.Set("Authorization", "Token "+authToken)
 
 	// Send request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClientFromMeta(m).Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -77,6 +87,9 @@ func resourceTeamCreate(d *schema.ResourceData, m interface{}) error {
 
     // Check HTTP status code
     if resp.StatusCode != http.StatusCreated {
+        if adopted, err := adoptExistingTeam(ctx, d, m, resp); adopted || err != nil {
+            return err
+        }
         return parseAPIError(resp)
     }
 
@@ -91,23 +104,26 @@ func resourceTeamCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 	d.SetId(result.ID)
+	if err := waitUntilReadable(ctx, m, fmt.Sprintf("https://app.valohai.com/api/v0/teams/%s/", result.ID), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("team %s was created but is not readable yet: %w", result.ID, err)
+	}
 	d.Set("name", result.Name)
 	d.Set("organization", result.Organization)
 	d.Set("url", result.URL)
 	return nil
 }
 
-func resourceTeamRead(d *schema.ResourceData, m interface{}) error {
+func resourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	authToken := m.(map[string]interface{})["token"].(string)
 	id := d.Id() // UUID du projet Valohai
 	url := fmt.Sprintf("https://app.valohai.com/api/v0/teams/%s/", id)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create GET request: %w", err)
 	}
 	req.Header.Set("Authorization", "Token "+authToken)
-	client := &http.Client{}
+	client := httpClientFromMeta(m)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute GET request: %w", err)
@@ -144,7 +160,10 @@ func resourceTeamRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceTeamUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceTeamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if !d.HasChangesExcept(localOnlyAttributes...) {
+		return nil
+	}
 	id := d.Id() // UUID du projet Valohai
 	apiURL := fmt.Sprintf("https://app.valohai.com/api/v0/teams/%s/", id)
 	authToken := m.(map[string]interface{})["token"].(string)
@@ -160,7 +179,7 @@ func resourceTeamUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequest("PUT", apiURL, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "PUT", apiURL, bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -168,7 +187,7 @@ func resourceTeamUpdate(d *schema.ResourceData, m interface{}) error {
 	req.Header.Set("Authorization", "Token "+authToken)
 
 	// Send request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClientFromMeta(m).Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -191,17 +210,17 @@ func resourceTeamUpdate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceTeamDelete(d *schema.ResourceData, m interface{}) error {
+func resourceTeamDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	authToken := m.(map[string]interface{})["token"].(string)
 	id := d.Id() // UUID du projet Valohai
 	url := fmt.Sprintf("https://app.valohai.com/api/v0/teams/%s/", id)
 
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create DELETE request: %w", err)
 	}
 	req.Header.Set("Authorization", "Token "+authToken)
-	client := &http.Client{}
+	client := httpClientFromMeta(m)
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute DELETE request: %w", err)
@@ -0,0 +1,477 @@
+package valohai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type triggerResource struct {
+	frameworkMeta
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &triggerResource{}
+	_ resource.ResourceWithImportState    = &triggerResource{}
+	_ resource.ResourceWithModifyPlan     = &triggerResource{}
+	_ resource.ResourceWithValidateConfig = &triggerResource{}
+)
+
+func newTriggerResource() resource.Resource {
+	return &triggerResource{}
+}
+
+// TriggerResource returns the valohai_trigger resource.
+func TriggerResource() resource.Resource {
+	return newTriggerResource()
+}
+
+// Trigger types, condition types and action types as named by the API.
+const (
+	triggerTypeScheduled = "scheduled"
+	triggerTypeWebhook   = "webhook"
+
+	triggerConditionCron    = "cron"
+	triggerConditionWebhook = "web-request-auth"
+
+	triggerActionCopyPipeline = "copy-pipeline"
+	triggerActionRunExecution = "run-execution"
+
+	defaultWebhookAuthType = "static-secret"
+)
+
+var webhookAuthTypes = []string{"static-secret", "hmac-sha256"}
+
+// triggerModel maps the valohai_trigger schema.
+type triggerModel struct {
+	ID           types.String               `tfsdk:"id"`
+	Project      types.String               `tfsdk:"project"`
+	Title        types.String               `tfsdk:"title"`
+	Enabled      types.Bool                 `tfsdk:"enabled"`
+	Type         types.String               `tfsdk:"type"`
+	WebhookURL   types.String               `tfsdk:"webhook_url"`
+	Schedule     []triggerScheduleModel     `tfsdk:"schedule"`
+	Webhook      []triggerWebhookModel      `tfsdk:"webhook"`
+	CopyPipeline []triggerCopyPipelineModel `tfsdk:"copy_pipeline"`
+	RunExecution []triggerRunExecutionModel `tfsdk:"run_execution"`
+}
+
+type triggerScheduleModel struct {
+	Cron types.String `tfsdk:"cron"`
+}
+
+type triggerWebhookModel struct {
+	AuthType types.String `tfsdk:"auth_type"`
+	Secret   types.String `tfsdk:"secret"`
+}
+
+type triggerCopyPipelineModel struct {
+	SourcePipeline   types.String `tfsdk:"source_pipeline"`
+	Title            types.String `tfsdk:"title"`
+	PayloadInputName types.String `tfsdk:"payload_input_name"`
+}
+
+type triggerRunExecutionModel struct {
+	SourceExecution  types.String `tfsdk:"source_execution"`
+	Title            types.String `tfsdk:"title"`
+	PayloadInputName types.String `tfsdk:"payload_input_name"`
+}
+
+// triggerAPI is a trigger as returned by the API. Webhook secrets are
+// write-only and never returned.
+type triggerAPI struct {
+	ID         string      `json:"id"`
+	Project    interface{} `json:"project"`
+	Title      string      `json:"title"`
+	Enabled    bool        `json:"enabled"`
+	WebhookURL string      `json:"webhook_url"`
+	Conditions []struct {
+		Type     string `json:"type"`
+		Schedule string `json:"schedule"`
+		AuthType string `json:"auth_type"`
+	} `json:"conditions"`
+	Actions []struct {
+		Type             string      `json:"type"`
+		SourcePipeline   interface{} `json:"source_pipeline"`
+		SourceExecution  interface{} `json:"source_execution"`
+		Title            string      `json:"title"`
+		PayloadInputName string      `json:"payload_input_name"`
+	} `json:"actions"`
+}
+
+func (r *triggerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trigger"
+}
+
+func (r *triggerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	optional := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{Optional: true, Description: description}
+	}
+	resp.Schema = schema.Schema{
+		Description: "Valohai trigger starting pipelines or executions on a cron schedule or on webhook requests.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the trigger.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project the trigger belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"title": schema.StringAttribute{
+				Required:    true,
+				Description: "Title of the trigger.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the trigger fires. Defaults to true.",
+			},
+			"type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the trigger, scheduled or webhook, derived from its condition. Changing it creates a new trigger.",
+			},
+			"webhook_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "URL to send webhook requests to. Empty for scheduled triggers.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"schedule": schema.ListNestedBlock{
+				Description: "Fires the trigger on a cron schedule. Exactly one of schedule or webhook is required.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"cron": schema.StringAttribute{
+							Required:    true,
+							Description: "Cron expression with five fields, evaluated in UTC.",
+						},
+					},
+				},
+			},
+			"webhook": schema.ListNestedBlock{
+				Description: "Fires the trigger on authenticated requests to webhook_url. Exactly one of schedule or webhook is required.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"auth_type": optional("How requests authenticate: static-secret (the secret is sent as is) or hmac-sha256 (the payload is signed with the secret). Defaults to static-secret."),
+						"secret": schema.StringAttribute{
+							Required:    true,
+							Sensitive:   true,
+							Description: "Secret authenticating webhook requests.",
+						},
+					},
+				},
+			},
+			"copy_pipeline": schema.ListNestedBlock{
+				Description: "Action copying an existing pipeline. At least one action is required.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"source_pipeline": schema.StringAttribute{
+							Required:    true,
+							Description: "ID of the pipeline to copy.",
+						},
+						"title":              optional("Title of the new pipeline."),
+						"payload_input_name": optional("Input of the new pipeline receiving the webhook payload."),
+					},
+				},
+			},
+			"run_execution": schema.ListNestedBlock{
+				Description: "Action running a copy of an existing execution. At least one action is required.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"source_execution": schema.StringAttribute{
+							Required:    true,
+							Description: "ID of the execution to copy.",
+						},
+						"title":              optional("Title of the new execution."),
+						"payload_input_name": optional("Input of the new execution receiving the webhook payload."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *triggerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.configure(req.ProviderData, &resp.Diagnostics)
+}
+
+// triggerBlocks are the condition and action blocks of a trigger.
+var triggerBlocks = []string{"schedule", "webhook", "copy_pipeline", "run_execution"}
+
+func (r *triggerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	known, diags := blocksKnown(ctx, req.Config.GetAttribute, triggerBlocks...)
+	resp.Diagnostics.Append(diags...)
+	if !known {
+		return
+	}
+	var data triggerModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(data.Schedule)+len(data.Webhook) != 1 {
+		resp.Diagnostics.AddError("Invalid trigger condition", "exactly one schedule or webhook block is required")
+	}
+	if len(data.CopyPipeline)+len(data.RunExecution) == 0 {
+		resp.Diagnostics.AddError("Missing trigger action", "at least one copy_pipeline or run_execution block is required")
+	}
+	for i, s := range data.Schedule {
+		if !s.Cron.IsUnknown() && len(strings.Fields(s.Cron.ValueString())) != 5 {
+			resp.Diagnostics.AddAttributeError(path.Root("schedule").AtListIndex(i).AtName("cron"), "Invalid cron expression",
+				fmt.Sprintf("expected five fields (minute hour day month weekday), got %q", s.Cron.ValueString()))
+		}
+	}
+	for i, w := range data.Webhook {
+		if w.AuthType.IsNull() || w.AuthType.IsUnknown() {
+			continue
+		}
+		valid := false
+		for _, t := range webhookAuthTypes {
+			valid = valid || w.AuthType.ValueString() == t
+		}
+		if !valid {
+			resp.Diagnostics.AddAttributeError(path.Root("webhook").AtListIndex(i).AtName("auth_type"), "Invalid auth_type",
+				fmt.Sprintf("expected one of %s, got %q", strings.Join(webhookAuthTypes, ", "), w.AuthType.ValueString()))
+		}
+	}
+}
+
+// ModifyPlan derives type from the configured condition. The API cannot turn
+// a scheduled trigger into a webhook one, so a type change forces replacement.
+func (r *triggerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// type stays unknown until the blocks are known
+	known, diags := blocksKnown(ctx, req.Plan.GetAttribute, triggerBlocks...)
+	resp.Diagnostics.Append(diags...)
+	if !known {
+		return
+	}
+	var plan triggerModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planned := plan.triggerType()
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), planned)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state triggerModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.Type.ValueString() != planned {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("type"))
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("webhook_url"), types.StringUnknown())...)
+	}
+}
+
+func (data *triggerModel) triggerType() string {
+	if len(data.Webhook) > 0 {
+		return triggerTypeWebhook
+	}
+	return triggerTypeScheduled
+}
+
+// payload builds the trigger as sent to the API.
+func (data *triggerModel) payload() map[string]interface{} {
+	conditions := []interface{}{}
+	for _, s := range data.Schedule {
+		conditions = append(conditions, map[string]interface{}{
+			"type":     triggerConditionCron,
+			"schedule": s.Cron.ValueString(),
+		})
+	}
+	for _, w := range data.Webhook {
+		authType := defaultWebhookAuthType
+		if !w.AuthType.IsNull() {
+			authType = w.AuthType.ValueString()
+		}
+		conditions = append(conditions, map[string]interface{}{
+			"type":      triggerConditionWebhook,
+			"auth_type": authType,
+			"secret":    w.Secret.ValueString(),
+		})
+	}
+
+	actions := []interface{}{}
+	for _, a := range data.CopyPipeline {
+		action := map[string]interface{}{
+			"type":            triggerActionCopyPipeline,
+			"source_pipeline": a.SourcePipeline.ValueString(),
+		}
+		setOptionalString(action, "title", a.Title)
+		setOptionalString(action, "payload_input_name", a.PayloadInputName)
+		actions = append(actions, action)
+	}
+	for _, a := range data.RunExecution {
+		action := map[string]interface{}{
+			"type":             triggerActionRunExecution,
+			"source_execution": a.SourceExecution.ValueString(),
+		}
+		setOptionalString(action, "title", a.Title)
+		setOptionalString(action, "payload_input_name", a.PayloadInputName)
+		actions = append(actions, action)
+	}
+
+	return map[string]interface{}{
+		"project":      data.Project.ValueString(),
+		"title":        data.Title.ValueString(),
+		"enabled":      data.Enabled.ValueBool(),
+		"trigger_type": data.triggerType(),
+		"conditions":   conditions,
+		"actions":      actions,
+	}
+}
+
+// setFromAPI copies the trigger returned by the API into data. Webhook
+// secrets are kept from data since the API never returns them.
+func (data *triggerModel) setFromAPI(result triggerAPI) {
+	data.ID = types.StringValue(result.ID)
+	data.Project = types.StringValue(apiID(result.Project))
+	data.Title = types.StringValue(result.Title)
+	data.Enabled = types.BoolValue(result.Enabled)
+	data.WebhookURL = types.StringValue(result.WebhookURL)
+
+	prior := data.Webhook
+	data.Schedule, data.Webhook = []triggerScheduleModel{}, []triggerWebhookModel{}
+	for _, c := range result.Conditions {
+		switch c.Type {
+		case triggerConditionCron:
+			data.Schedule = append(data.Schedule, triggerScheduleModel{Cron: types.StringValue(c.Schedule)})
+		case triggerConditionWebhook:
+			w := triggerWebhookModel{AuthType: types.StringValue(c.AuthType), Secret: types.StringNull()}
+			if i := len(data.Webhook); i < len(prior) {
+				w.Secret = prior[i].Secret
+				if prior[i].AuthType.IsNull() && c.AuthType == defaultWebhookAuthType {
+					w.AuthType = types.StringNull()
+				}
+			}
+			data.Webhook = append(data.Webhook, w)
+		}
+	}
+
+	data.CopyPipeline, data.RunExecution = []triggerCopyPipelineModel{}, []triggerRunExecutionModel{}
+	for _, a := range result.Actions {
+		switch a.Type {
+		case triggerActionCopyPipeline:
+			data.CopyPipeline = append(data.CopyPipeline, triggerCopyPipelineModel{
+				SourcePipeline:   types.StringValue(apiID(a.SourcePipeline)),
+				Title:            optionalString(a.Title),
+				PayloadInputName: optionalString(a.PayloadInputName),
+			})
+		case triggerActionRunExecution:
+			data.RunExecution = append(data.RunExecution, triggerRunExecutionModel{
+				SourceExecution:  types.StringValue(apiID(a.SourceExecution)),
+				Title:            optionalString(a.Title),
+				PayloadInputName: optionalString(a.PayloadInputName),
+			})
+		}
+	}
+	data.Type = types.StringValue(data.triggerType())
+}
+
+func triggerURL(id string) string {
+	return fmt.Sprintf("https://app.valohai.com/api/v0/triggers/%s/", id)
+}
+
+func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data triggerModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result triggerAPI
+	if err := doJSON(ctx, r.meta, http.MethodPost, "https://app.valohai.com/api/v0/triggers/", data.payload(), &result); err != nil {
+		resp.Diagnostics.AddError("Failed to create trigger", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(result.ID)
+	data.WebhookURL = types.StringValue(result.WebhookURL)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data triggerModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result triggerAPI
+	err := doJSON(ctx, r.meta, http.MethodGet, triggerURL(data.ID.ValueString()), nil, &result)
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read trigger", err.Error())
+		return
+	}
+
+	data.setFromAPI(result)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *triggerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data triggerModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result triggerAPI
+	if err := doJSON(ctx, r.meta, http.MethodPatch, triggerURL(data.ID.ValueString()), data.payload(), &result); err != nil {
+		resp.Diagnostics.AddError("Failed to update trigger", err.Error())
+		return
+	}
+
+	data.WebhookURL = types.StringValue(result.WebhookURL)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *triggerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data triggerModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := doJSON(ctx, r.meta, http.MethodDelete, triggerURL(data.ID.ValueString()), nil, nil)
+	if err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Failed to delete trigger", err.Error())
+	}
+}
+
+// ImportState adopts an existing trigger by ID. Webhook secrets cannot be read
+// back, so the first apply after import sets them from the configuration.
+func (r *triggerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,105 @@
+package valohai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Each resource keeps a frozen copy of its version 0 schema: the upgraders
+// decode raw state with it, so it must not follow later schema changes.
+//
+// valohai_team and valohai_registry_credentials stay at version 0: their
+// attributes kept the types they were first stored with (organization and
+// owner as numbers, configuration as a map of strings), so existing state
+// decodes with the current schema. They get a version and an upgrader with
+// their first breaking change.
+
+func resourceProjectV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":                  {Type: schema.TypeString, Required: true},
+			"owner":                 {Type: schema.TypeString, Required: true},
+			"description":           {Type: schema.TypeString, Optional: true},
+			"template_url":          {Type: schema.TypeString, Optional: true},
+			"default_notifications": {Type: schema.TypeString, Optional: true},
+		},
+	}
+}
+
+// resourceProjectStateUpgradeV0 converts default_notifications from the
+// "true"/"false" string used in version 0 to a boolean.
+func resourceProjectStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	switch v := rawState["default_notifications"].(type) {
+	case nil:
+	case bool:
+	case string:
+		rawState["default_notifications"] = strings.EqualFold(strings.TrimSpace(v), "true")
+	default:
+		return nil, fmt.Errorf("unexpected default_notifications value %v (%T) in state", v, v)
+	}
+	return rawState, nil
+}
+
+func resourceProjectV1() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":                  {Type: schema.TypeString, Required: true},
+			"owner":                 {Type: schema.TypeString, Required: true},
+			"description":           {Type: schema.TypeString, Optional: true},
+			"template_url":          {Type: schema.TypeString, Optional: true},
+			"default_notifications": {Type: schema.TypeBool, Optional: true, Computed: true},
+			"url":                   {Type: schema.TypeString, Computed: true},
+			"ctime":                 {Type: schema.TypeString, Computed: true},
+			"mtime":                 {Type: schema.TypeString, Computed: true},
+		},
+	}
+}
+
+// resourceProjectStateUpgradeV1 enables deletion_protection on projects that
+// were created before it existed, matching its default.
+func resourceProjectStateUpgradeV1(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	if _, ok := rawState["deletion_protection"]; !ok || rawState["deletion_protection"] == nil {
+		rawState["deletion_protection"] = true
+	}
+	return rawState, nil
+}
+
+func resourceStoreV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":               {Type: schema.TypeString, Required: true},
+			"type":               {Type: schema.TypeString, Required: true},
+			"access_mode":        {Type: schema.TypeString, Optional: true},
+			"allow_read":         {Type: schema.TypeBool, Optional: true},
+			"allow_write":        {Type: schema.TypeBool, Optional: true},
+			"allow_uri_download": {Type: schema.TypeBool, Optional: true},
+			"configuration":      {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"owner_id":           {Type: schema.TypeInt, Optional: true},
+			"project":            {Type: schema.TypeString, Optional: true},
+			"paths":              {Type: schema.TypeMap, Optional: true},
+			"teams":              {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"url":                {Type: schema.TypeString, Computed: true},
+		},
+	}
+}
+
+// resourceStoreStateUpgradeV0 sets deletion_protection to its default on
+// stores that were created before it existed, so they plan no change.
+func resourceStoreStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	if _, ok := rawState["deletion_protection"]; !ok || rawState["deletion_protection"] == nil {
+		rawState["deletion_protection"] = false
+	}
+	return rawState, nil
+}
@@ -0,0 +1,43 @@
+package valohai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// defaultCreateTimeout bounds how long a create waits for the new object to
+// become readable.
+const defaultCreateTimeout = 2 * time.Minute
+
+// waitUntilReadable polls apiURL until the object created just before answers
+// 200. The API is briefly eventually consistent after a create, so 404 is
+// retried until timeout; any other error status stops the polling.
+func waitUntilReadable(ctx context.Context, m interface{}, apiURL string, timeout time.Duration) error {
+	authToken := m.(map[string]interface{})["token"].(string)
+	client := httpClientFromMeta(m)
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+		if err != nil {
+			return retry.NonRetryableError(fmt.Errorf("failed to create GET request: %w", err))
+		}
+		req.Header.Set("Authorization", "Token "+authToken)
+		resp, err := client.Do(req)
+		if err != nil {
+			return retry.NonRetryableError(fmt.Errorf("failed to execute GET request: %w", err))
+		}
+		defer resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return nil
+		case http.StatusNotFound:
+			return retry.RetryableError(fmt.Errorf("%s is not readable yet", apiURL))
+		}
+		return retry.NonRetryableError(parseAPIError(resp))
+	})
+}